@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"mcp-dungeon/llm"
+	"mcp-dungeon/models"
+	"mcp-dungeon/storage"
+)
+
+var (
+	enrichOutputFile string
+	enrichBaseURL    string
+	enrichChatModel  string
+)
+
+func runEnrichDungeon(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	if err := checkDungeonFile(inputFile); err != nil {
+		return err
+	}
+
+	dungeon, err := storage.LoadDungeonFromYAML(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to load dungeon: %v", err)
+	}
+
+	client := llm.NewClient(enrichBaseURL, enrichChatModel)
+	log.Printf("Enriching %s with %s (%s)", inputFile, enrichChatModel, enrichBaseURL)
+
+	// The template is left untouched until every location is enriched
+	enriched := *dungeon
+	enriched.Locations = maps.Clone(dungeon.Locations)
+
+	for _, id := range slices.Sorted(maps.Keys(dungeon.Locations)) {
+		if dungeon.Locations[id].Type == models.LocationTypeObstacle {
+			continue
+		}
+		location, err := llm.EnrichLocation(cmd.Context(), client, dungeon, id)
+		if err != nil {
+			return fmt.Errorf("failed to enrich '%s': %v", id, err)
+		}
+		enriched.Locations[id] = location
+		log.Printf("🟢 %s: %s", id, location.Description)
+	}
+
+	if err := storage.SaveDungeonToYAML(&enriched, enrichOutputFile); err != nil {
+		return fmt.Errorf("failed to save dungeon: %v", err)
+	}
+	log.Printf("Enriched dungeon written to %s", enrichOutputFile)
+	return nil
+}
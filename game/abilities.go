@@ -0,0 +1,97 @@
+package game
+
+import (
+	"fmt"
+
+	"mcp-dungeon/models"
+)
+
+// StealDifficulty is the base difficulty of a steal: the thief succeeds when
+// 1d20 + its level + its agility modifier reaches StealDifficulty + half the monster difficulty.
+const StealDifficulty = 10
+
+// AbilityResult describes the use of the signature ability of the player class.
+type AbilityResult struct {
+	Ability    string
+	Player     models.Player
+	Monster    models.Monster
+	Round      CombatRound
+	Experience int
+	LevelUps   []LevelUp
+	// Treasure is the treasure won by defeating or robbing the monster
+	Treasure models.Treasure
+	// Stolen reports whether a steal succeeded
+	Stolen bool
+}
+
+// UseAbility uses the signature ability of the player class against the monster of
+// the current room. An ability can be used once per visit of a room.
+func (e *Engine) UseAbility() (AbilityResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return AbilityResult{}, fmt.Errorf("Player %s is dead and cannot use abilities", e.player.Name)
+	}
+
+	class, err := LookupClass(e.player.Type)
+	if err != nil {
+		return AbilityResult{}, fmt.Errorf("Player %s has an %v", e.player.Name, err)
+	}
+
+	roomID := e.player.CurrentLocation
+	currentLocation, err := e.currentLocation()
+	if err != nil {
+		return AbilityResult{}, err
+	}
+
+	if !HasLivingMonster(currentLocation) {
+		return AbilityResult{}, fmt.Errorf("There is no living monster in room '%s'", roomID)
+	}
+
+	if e.world.AbilityUsedDuringVisit(roomID) {
+		return AbilityResult{}, fmt.Errorf("%s already used %s in room '%s', leave the room and come back later",
+			e.player.Name, class.Ability, roomID)
+	}
+
+	monster := currentLocation.Monster
+	result := AbilityResult{Ability: class.Ability}
+
+	switch class.Ability {
+	case AbilityPowerStrike:
+		result.Round = ResolveStrike(e.random, e.player, monster, 2*PlayerDamage(e.random, e.player, monster), true)
+	case AbilitySpell:
+		damage := max(1, EffectiveAttackPower(e.player)/2+spellRoll.Roll(e.random).Total+2*SkillModifier(e.player.Intelligence))
+		result.Round = ResolveStrike(e.random, e.player, monster, damage, false)
+	case AbilitySteal:
+		if monster.Treasure.Type == "" {
+			return AbilityResult{}, fmt.Errorf("%s has no treasure to steal", monster.Name)
+		}
+		if stealRoll.Roll(e.random).Total+e.player.Level+SkillModifier(e.player.Agility) >= StealDifficulty+monster.DifficultyLevel/2 {
+			result.Stolen = true
+			result.Treasure = monster.Treasure
+			AwardTreasure(e.player, monster.Treasure)
+			e.world.StealTreasure(roomID)
+		} else {
+			// Caught in the act: the monster strikes
+			result.Round = ResolveStrike(e.random, e.player, monster, 0, true)
+		}
+	}
+
+	e.world.UseAbility(roomID)
+	if !result.Stolen {
+		// A strike or a thief caught in the act starts a fight, a clean steal does not
+		e.world.StartFight(roomID)
+		e.world.SetMonsterHitPoints(roomID, monster.HitPoints)
+	}
+	e.changes++
+
+	if result.Round.MonsterDefeated {
+		result.Treasure = monster.Treasure
+		result.Experience, result.LevelUps = ClaimVictory(e.player, monster, e.progression)
+	}
+
+	result.Player = *ClonePlayer(e.player)
+	result.Monster = *monster
+	return result, nil
+}
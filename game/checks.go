@@ -0,0 +1,186 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"mcp-dungeon/game/dice"
+	"mcp-dungeon/models"
+)
+
+// Outcomes of a skill check. A natural 20 always succeeds and a natural 1 always fails.
+const (
+	OutcomeCriticalSuccess = "critical success"
+	OutcomeSuccess         = "success"
+	OutcomeFailure         = "failure"
+	OutcomeCriticalFailure = "critical failure"
+)
+
+// EventSkillCheck is the kind of the events logged by skill checks.
+const EventSkillCheck = "skill_check"
+
+var checkRoll = dice.MustParse("1d20")
+
+// SkillCheckResult describes a skill check of the player.
+type SkillCheckResult struct {
+	// Check is the check of the room that was attempted, nil for a free check
+	Check      *models.Check
+	Skill      string
+	Difficulty int
+	Roll       int
+	Modifier   int
+	Total      int
+	Outcome    string
+	Success    bool
+	// Consequences of a check of the room
+	Opened     string
+	Reward     models.Treasure
+	Experience int
+	LevelUps   []LevelUp
+	Damage     int
+	Player     models.Player
+}
+
+// IsDoorLocked reports whether the door between two connected locations is still locked.
+// A door listed in the locked_doors of either location is locked until a check opens it.
+func IsDoorLocked(from, to models.Location) bool {
+	return slices.Contains(from.LockedDoors, to.ID) || slices.Contains(to.LockedDoors, from.ID)
+}
+
+// rollCheck rolls 1d20 + the modifier of a skill of the player against a difficulty class.
+// It must be called with the lock held.
+func (e *Engine) rollCheck(skill string, difficulty int) (SkillCheckResult, error) {
+	score, known := PlayerSkill(e.player, skill)
+	if !known {
+		return SkillCheckResult{}, fmt.Errorf("Unknown skill '%s' (expected one of %v)", skill, SkillNames)
+	}
+	if difficulty < models.MinDifficultyClass || difficulty > models.MaxDifficultyClass {
+		return SkillCheckResult{}, fmt.Errorf("Difficulty class %d is out of range [%d, %d]",
+			difficulty, models.MinDifficultyClass, models.MaxDifficultyClass)
+	}
+
+	result := SkillCheckResult{
+		Skill:      skill,
+		Difficulty: difficulty,
+		Roll:       checkRoll.Roll(e.random).Total,
+		Modifier:   SkillModifier(score),
+	}
+	result.Total = result.Roll + result.Modifier
+
+	switch {
+	case result.Roll == 20:
+		result.Outcome = OutcomeCriticalSuccess
+	case result.Roll == 1:
+		result.Outcome = OutcomeCriticalFailure
+	case result.Total >= difficulty:
+		result.Outcome = OutcomeSuccess
+	default:
+		result.Outcome = OutcomeFailure
+	}
+	result.Success = result.Outcome == OutcomeCriticalSuccess || result.Outcome == OutcomeSuccess
+	return result, nil
+}
+
+// logCheck records a skill check in the event log. It must be called with the lock held.
+func (e *Engine) logCheck(action string, result SkillCheckResult) {
+	e.world.LogEvent(EventSkillCheck, e.player.CurrentLocation, fmt.Sprintf("%s %s (%s DC %d): rolled %d%+d = %d, %s",
+		e.player.Name, action, result.Skill, result.Difficulty, result.Roll, result.Modifier, result.Total, result.Outcome))
+}
+
+// SkillCheck rolls a free skill check, with no consequence other than its outcome.
+func (e *Engine) SkillCheck(skill string, difficulty int) (SkillCheckResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return SkillCheckResult{}, fmt.Errorf("Player %s is dead and cannot attempt checks", e.player.Name)
+	}
+
+	result, err := e.rollCheck(skill, difficulty)
+	if err != nil {
+		return SkillCheckResult{}, err
+	}
+
+	e.logCheck("attempted a check", result)
+	e.changes++
+
+	result.Player = *ClonePlayer(e.player)
+	return result, nil
+}
+
+// AttemptCheck attempts a check of the current room and applies its consequences: a success
+// opens its door and gives its rewards, and the check cannot be attempted again; a failure
+// deals its damage, doubled on a critical failure, and the check can only be attempted
+// again on a later visit.
+func (e *Engine) AttemptCheck(name string) (SkillCheckResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return SkillCheckResult{}, fmt.Errorf("Player %s is dead and cannot attempt checks", e.player.Name)
+	}
+
+	roomID := e.player.CurrentLocation
+	currentLocation, err := e.currentLocation()
+	if err != nil {
+		return SkillCheckResult{}, err
+	}
+
+	index := slices.IndexFunc(currentLocation.Checks, func(check models.Check) bool { return check.Name == name })
+	if index < 0 {
+		return SkillCheckResult{}, fmt.Errorf("There is no check '%s' to attempt in room '%s'", name, roomID)
+	}
+	check := currentLocation.Checks[index]
+
+	if e.world.CheckFailedDuringVisit(roomID, name) {
+		return SkillCheckResult{}, fmt.Errorf("%s already failed to %s, leave the room and come back later",
+			e.player.Name, CheckAction(check))
+	}
+
+	result, err := e.rollCheck(check.Skill, check.Difficulty)
+	if err != nil {
+		return SkillCheckResult{}, fmt.Errorf("Check '%s' of room '%s' is invalid: %v", name, roomID, err)
+	}
+	result.Check = &check
+
+	if result.Success {
+		e.world.PassCheck(roomID, name)
+		if check.Opens != "" {
+			e.world.OpenDoor(roomID, check.Opens)
+			result.Opened = check.Opens
+		}
+		if check.Reward != nil {
+			result.Reward = *check.Reward
+			AwardTreasure(e.player, *check.Reward)
+		}
+		if check.Experience > 0 {
+			result.Experience = check.Experience
+			result.LevelUps = GainExperience(e.player, e.progression, check.Experience)
+		}
+	} else {
+		e.world.FailCheck(roomID, name)
+		result.Damage = check.Damage
+		if result.Outcome == OutcomeCriticalFailure {
+			result.Damage *= 2
+		}
+		e.player.HitPoints = max(0, e.player.HitPoints-result.Damage)
+		UpdatePlayerStatus(e.player)
+	}
+
+	e.logCheck("tried to "+CheckAction(check), result)
+	e.changes++
+
+	result.Player = *ClonePlayer(e.player)
+	return result, nil
+}
+
+// CheckAction describes what the player does in a check, e.g. "force the rusted door".
+func CheckAction(check models.Check) string {
+	if check.Description == "" {
+		return "pass the check '" + check.Name + "'"
+	}
+	first, size := utf8.DecodeRuneInString(check.Description)
+	return string(unicode.ToLower(first)) + check.Description[size:]
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"mcp-dungeon/models"
+)
+
+// Signature abilities of the classes, used with the use_ability tool.
+const (
+	AbilityPowerStrike = "power_strike"
+	AbilitySpell       = "spell"
+	AbilitySteal       = "steal"
+)
+
+// Class is a character class: the stats a player starts with, the stats it gains
+// at each level up and its signature ability.
+type Class struct {
+	Name               string
+	Description        string
+	Avatar             string
+	Base               models.StatGrowth
+	Skills             models.Skills
+	Growth             models.StatGrowth
+	Ability            string
+	AbilityDescription string
+}
+
+// Classes is the registry of the playable classes, keyed by player type.
+var Classes = map[string]Class{
+	"warrior": {
+		Name:               "warrior",
+		Description:        "A sturdy fighter who trusts steel and armor",
+		Avatar:             "🗡️",
+		Base:               models.StatGrowth{MaxHitPoints: 100, AttackPower: 15, Defense: 10},
+		Skills:             models.Skills{Strength: 15, Agility: 11, Intelligence: 8},
+		Growth:             models.StatGrowth{MaxHitPoints: 15, AttackPower: 3, Defense: 2},
+		Ability:            AbilityPowerStrike,
+		AbilityDescription: "A blow dealing twice the usual damage",
+	},
+	"mage": {
+		Name:               "mage",
+		Description:        "A frail scholar wielding destructive magic",
+		Avatar:             "🧙",
+		Base:               models.StatGrowth{MaxHitPoints: 70, AttackPower: 18, Defense: 6},
+		Skills:             models.Skills{Strength: 8, Agility: 10, Intelligence: 16},
+		Growth:             models.StatGrowth{MaxHitPoints: 8, AttackPower: 4, Defense: 1},
+		Ability:            AbilitySpell,
+		AbilityDescription: "An arcane bolt that ignores the monster's toughness, cast from afar so the monster cannot strike back",
+	},
+	"thief": {
+		Name:               "thief",
+		Description:        "A nimble rogue who prefers not to fight fair",
+		Avatar:             "🥷",
+		Base:               models.StatGrowth{MaxHitPoints: 85, AttackPower: 14, Defense: 8},
+		Skills:             models.Skills{Strength: 10, Agility: 16, Intelligence: 12},
+		Growth:             models.StatGrowth{MaxHitPoints: 10, AttackPower: 3, Defense: 1},
+		Ability:            AbilitySteal,
+		AbilityDescription: "Sneak up on the monster and steal its treasure without fighting",
+	},
+}
+
+// ClassNames returns the names of the playable classes, sorted.
+func ClassNames() []string {
+	return slices.Sorted(maps.Keys(Classes))
+}
+
+// LookupClass returns the class of a player type.
+func LookupClass(name string) (Class, error) {
+	class, exists := Classes[name]
+	if !exists {
+		return Class{}, fmt.Errorf("unknown class '%s' (expected one of %s)", name, strings.Join(ClassNames(), ", "))
+	}
+	return class, nil
+}
+
+// NewPlayer creates a level 1 player of a class, with the base stats and skills of the class.
+// An empty avatar gives the avatar of the class.
+func NewPlayer(name, avatar, className string) (*models.Player, error) {
+	class, err := LookupClass(className)
+	if err != nil {
+		return nil, err
+	}
+	if avatar == "" {
+		avatar = class.Avatar
+	}
+	return &models.Player{
+		Name:         name,
+		Avatar:       avatar,
+		Type:         class.Name,
+		Level:        1,
+		HitPoints:    class.Base.MaxHitPoints,
+		MaxHitPoints: class.Base.MaxHitPoints,
+		AttackPower:  class.Base.AttackPower,
+		Defense:      class.Base.Defense,
+		Skills:       class.Skills,
+		Status:       StatusHealthy,
+	}, nil
+}
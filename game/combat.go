@@ -0,0 +1,71 @@
+package game
+
+import (
+	"mcp-dungeon/game/dice"
+	"mcp-dungeon/models"
+)
+
+// Dice of the combat rules.
+var (
+	strikeRoll = dice.MustParse("1d6")
+	spellRoll  = dice.MustParse("3d6")
+	stealRoll  = dice.MustParse("1d20")
+)
+
+// CombatRound is the outcome of one exchange of blows between the player and a monster.
+type CombatRound struct {
+	PlayerDamage    int
+	MonsterDamage   int
+	MonsterDefeated bool
+	PlayerDefeated  bool
+}
+
+// HasLivingMonster reports whether a monster is still standing in the location.
+func HasLivingMonster(location models.Location) bool {
+	return location.Monster != nil && location.Monster.HitPoints > 0
+}
+
+// PlayerDamage rolls the damage of a regular blow of the player, boosted by its strength and its equipment.
+func PlayerDamage(source dice.Source, player *models.Player, monster *models.Monster) int {
+	return max(1, EffectiveAttackPower(player)+SkillModifier(player.Strength)+strikeRoll.Roll(source).Total-monster.DifficultyLevel)
+}
+
+// ResolveCombatRound plays one round: the player strikes first, then the
+// monster strikes back if it is still standing. Damage is applied to both sides.
+func ResolveCombatRound(source dice.Source, player *models.Player, monster *models.Monster) CombatRound {
+	return ResolveStrike(source, player, monster, PlayerDamage(source, player, monster), true)
+}
+
+// ResolveStrike applies the damage of a strike of the player to the monster, then lets the
+// monster strike back if it is still standing and the strike allows a counterattack.
+func ResolveStrike(source dice.Source, player *models.Player, monster *models.Monster, damage int, counterattack bool) CombatRound {
+	var round CombatRound
+
+	round.PlayerDamage = damage
+	monster.HitPoints = max(0, monster.HitPoints-round.PlayerDamage)
+	if monster.HitPoints == 0 {
+		round.MonsterDefeated = true
+		return round
+	}
+	if !counterattack {
+		return round
+	}
+
+	round.MonsterDamage = max(0, monster.DifficultyLevel*3+strikeRoll.Roll(source).Total-EffectiveDefense(player))
+	player.HitPoints = max(0, player.HitPoints-round.MonsterDamage)
+	UpdatePlayerStatus(player)
+	round.PlayerDefeated = IsDead(player)
+
+	return round
+}
+
+// ClaimVictory rewards the player for a defeated monster, with experience scaled by
+// the monster difficulty, and returns the experience earned and the levels gained.
+func ClaimVictory(player *models.Player, monster *models.Monster, progression *models.Progression) (int, []LevelUp) {
+	experience := monster.DifficultyLevel * progression.MonsterExperience
+	levelUps := GainExperience(player, progression, experience)
+
+	AwardTreasure(player, monster.Treasure)
+
+	return experience, levelUps
+}
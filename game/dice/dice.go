@@ -0,0 +1,452 @@
+// Package dice rolls dice expressions written in the usual tabletop notation,
+// like "2d6+3", "4d6 drop lowest" or "1d20 with advantage".
+package dice
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Limits of an expression, so that a roll stays cheap.
+const (
+	MaxGroups = 10
+	MaxDice   = 100
+	MaxSides  = 1000
+)
+
+// Source is the random generator used to roll the dice. *rand.Rand of math/rand/v2
+// is a Source.
+type Source interface {
+	// IntN returns a number in [0, n).
+	IntN(n int) int
+}
+
+type defaultSource struct{}
+
+func (defaultSource) IntN(n int) int {
+	return rand.IntN(n)
+}
+
+// DefaultSource rolls the dice with the global generator of math/rand/v2.
+var DefaultSource Source = defaultSource{}
+
+// Group is a group of identical dice of an expression, like "4d6 drop lowest".
+type Group struct {
+	Count int
+	Sides int
+	// Negative groups are subtracted from the total
+	Negative bool
+	// Drop is the number of dice left out of the total: the lowest ones,
+	// or the highest ones with DropHighest
+	Drop        int
+	DropHighest bool
+	// Advantage rolls the group twice and keeps the best total,
+	// Disadvantage keeps the worst one
+	Advantage    bool
+	Disadvantage bool
+}
+
+func (g Group) String() string {
+	var text strings.Builder
+	if g.Negative {
+		text.WriteString("-")
+	}
+	fmt.Fprintf(&text, "%dd%d", g.Count, g.Sides)
+	if g.Drop > 0 {
+		text.WriteString(" drop ")
+		if g.DropHighest {
+			text.WriteString("highest")
+		} else {
+			text.WriteString("lowest")
+		}
+		if g.Drop > 1 {
+			fmt.Fprintf(&text, " %d", g.Drop)
+		}
+	}
+	if g.Advantage {
+		text.WriteString(" with advantage")
+	}
+	if g.Disadvantage {
+		text.WriteString(" with disadvantage")
+	}
+	return text.String()
+}
+
+// Expression is a parsed dice expression: groups of dice plus a fixed modifier.
+type Expression struct {
+	Groups   []Group
+	Modifier int
+}
+
+func (e Expression) String() string {
+	var text strings.Builder
+	for i, group := range e.Groups {
+		switch {
+		case i > 0 && group.Negative:
+			text.WriteString(" - ")
+			group.Negative = false
+		case i > 0:
+			text.WriteString(" + ")
+		}
+		text.WriteString(group.String())
+	}
+	switch {
+	case e.Modifier > 0:
+		fmt.Fprintf(&text, " + %d", e.Modifier)
+	case e.Modifier < 0:
+		fmt.Fprintf(&text, " - %d", -e.Modifier)
+	}
+	return text.String()
+}
+
+// Die is one rolled die.
+type Die struct {
+	Sides int `json:"sides"`
+	Value int `json:"value"`
+	// Dropped dice do not count in the total
+	Dropped bool `json:"dropped,omitempty"`
+}
+
+// GroupResult is the roll of a group of dice. Its total is negative for a negative group.
+type GroupResult struct {
+	Notation string `json:"notation"`
+	Dice     []Die  `json:"dice"`
+	Total    int    `json:"total"`
+}
+
+// Result is the roll of an expression: every die, the modifier and the total.
+type Result struct {
+	Expression string        `json:"expression"`
+	Groups     []GroupResult `json:"groups"`
+	Modifier   int           `json:"modifier"`
+	Total      int           `json:"total"`
+}
+
+// Parse reads a dice expression. Groups of dice ("2d6", "d20", "d%") and numbers are
+// added or subtracted, and each group can be followed by one of:
+//   - "drop lowest [n]", "drop highest [n]", or the short forms "dl[n]" and "dh[n]"
+//   - "keep highest [n]", "keep lowest [n]", or the short forms "kh[n]" and "kl[n]"
+//   - "with advantage" or "with disadvantage" ("adv" and "dis" for short)
+func Parse(text string) (Expression, error) {
+	p := &parser{tokens: tokenize(strings.ToLower(text))}
+	expression, err := p.expression()
+	if err != nil {
+		return Expression{}, fmt.Errorf("invalid dice expression '%s': %v", text, err)
+	}
+	return expression, nil
+}
+
+// MustParse is like Parse but panics on an invalid expression.
+// It is meant for the expressions written in the code.
+func MustParse(text string) Expression {
+	expression, err := Parse(text)
+	if err != nil {
+		panic(err)
+	}
+	return expression
+}
+
+// Roll parses an expression and rolls it.
+func Roll(source Source, text string) (Result, error) {
+	expression, err := Parse(text)
+	if err != nil {
+		return Result{}, err
+	}
+	return expression.Roll(source), nil
+}
+
+// Roll rolls every group of dice of the expression.
+func (e Expression) Roll(source Source) Result {
+	result := Result{Expression: e.String(), Modifier: e.Modifier, Total: e.Modifier}
+	for _, group := range e.Groups {
+		groupResult := group.Roll(source)
+		result.Groups = append(result.Groups, groupResult)
+		result.Total += groupResult.Total
+	}
+	return result
+}
+
+// Roll rolls the dice of the group.
+func (g Group) Roll(source Source) GroupResult {
+	dice, total := g.rollOnce(source)
+	if g.Advantage || g.Disadvantage {
+		second, secondTotal := g.rollOnce(source)
+		if (g.Advantage && secondTotal > total) || (g.Disadvantage && secondTotal < total) {
+			dice, second = second, dice
+			total = secondTotal
+		}
+		for i := range second {
+			second[i].Dropped = true
+		}
+		dice = append(dice, second...)
+	}
+
+	if g.Negative {
+		total = -total
+	}
+	return GroupResult{Notation: g.String(), Dice: dice, Total: total}
+}
+
+// rollOnce rolls Count dice, drops the ones to drop and returns the sum of the others.
+func (g Group) rollOnce(source Source) ([]Die, int) {
+	dice := make([]Die, g.Count)
+	for i := range dice {
+		dice[i] = Die{Sides: g.Sides, Value: source.IntN(g.Sides) + 1}
+	}
+
+	for range g.Drop {
+		index := -1
+		for i, die := range dice {
+			if die.Dropped {
+				continue
+			}
+			if index < 0 || (g.DropHighest && die.Value > dice[index].Value) ||
+				(!g.DropHighest && die.Value < dice[index].Value) {
+				index = i
+			}
+		}
+		dice[index].Dropped = true
+	}
+
+	total := 0
+	for _, die := range dice {
+		if !die.Dropped {
+			total += die.Value
+		}
+	}
+	return dice, total
+}
+
+// tokenize splits an expression into numbers, words and symbols, ignoring spaces.
+func tokenize(text string) []string {
+	var tokens []string
+	for i := 0; i < len(text); {
+		c := text[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case c >= '0' && c <= '9':
+			for i < len(text) && text[i] >= '0' && text[i] <= '9' {
+				i++
+			}
+		case c >= 'a' && c <= 'z':
+			// "d" is a word of its own so that "d6" and "4d6dl1" split as expected
+			i++
+			if c != 'd' || (i < len(text) && (text[i] == 'l' || text[i] == 'h' || text[i] == 'i' || text[i] == 'r')) {
+				for i < len(text) && text[i] >= 'a' && text[i] <= 'z' {
+					i++
+				}
+			}
+		default:
+			i++
+		}
+		tokens = append(tokens, text[start:i])
+	}
+	return tokens
+}
+
+type parser struct {
+	tokens []string
+	next   int
+}
+
+func (p *parser) peek() string {
+	if p.next < len(p.tokens) {
+		return p.tokens[p.next]
+	}
+	return ""
+}
+
+func (p *parser) take() string {
+	token := p.peek()
+	if token != "" {
+		p.next++
+	}
+	return token
+}
+
+// quote names a token in an error message.
+func quote(token string) string {
+	if token == "" {
+		return "the end of the expression"
+	}
+	return "'" + token + "'"
+}
+
+func isNumber(token string) bool {
+	return token != "" && token[0] >= '0' && token[0] <= '9'
+}
+
+func (p *parser) number() (int, error) {
+	token := p.take()
+	if !isNumber(token) {
+		return 0, fmt.Errorf("expected a number, got %s", quote(token))
+	}
+	return strconv.Atoi(token)
+}
+
+// optionalNumber reads a number if there is one, or returns the default value.
+func (p *parser) optionalNumber(defaultValue int) (int, error) {
+	if isNumber(p.peek()) {
+		return p.number()
+	}
+	return defaultValue, nil
+}
+
+func (p *parser) expression() (Expression, error) {
+	var expression Expression
+	negative := false
+	if p.peek() == "-" || p.peek() == "+" {
+		negative = p.take() == "-"
+	}
+
+	for {
+		if err := p.term(&expression, negative); err != nil {
+			return Expression{}, err
+		}
+
+		switch token := p.take(); token {
+		case "":
+			if len(expression.Groups) == 0 {
+				return Expression{}, fmt.Errorf("there are no dice to roll")
+			}
+			return expression, nil
+		case "+", "-":
+			negative = token == "-"
+		default:
+			return Expression{}, fmt.Errorf("unexpected '%s'", token)
+		}
+	}
+}
+
+func (p *parser) term(expression *Expression, negative bool) error {
+	count := 1
+	if isNumber(p.peek()) {
+		value, err := p.number()
+		if err != nil {
+			return err
+		}
+		if p.peek() != "d" {
+			if negative {
+				value = -value
+			}
+			expression.Modifier += value
+			return nil
+		}
+		count = value
+	}
+
+	if token := p.take(); token != "d" {
+		return fmt.Errorf("expected a number or dice, got %s", quote(token))
+	}
+
+	group := Group{Count: count, Negative: negative}
+	if p.peek() == "%" {
+		p.take()
+		group.Sides = 100
+	} else {
+		sides, err := p.number()
+		if err != nil {
+			return err
+		}
+		group.Sides = sides
+	}
+
+	if group.Count < 1 || group.Count > MaxDice {
+		return fmt.Errorf("%d dice is out of range [1, %d]", group.Count, MaxDice)
+	}
+	if group.Sides < 2 || group.Sides > MaxSides {
+		return fmt.Errorf("%d sides is out of range [2, %d]", group.Sides, MaxSides)
+	}
+
+	if err := p.options(&group); err != nil {
+		return err
+	}
+
+	expression.Groups = append(expression.Groups, group)
+	if len(expression.Groups) > MaxGroups {
+		return fmt.Errorf("more than %d groups of dice", MaxGroups)
+	}
+	return nil
+}
+
+// options reads the drop, keep and advantage rules that follow a group of dice.
+func (p *parser) options(group *Group) error {
+	// A rule may leave the group unchanged, like keeping every die, so the rules
+	// read are counted rather than guessed from the group
+	ruled := false
+	for {
+		token := p.peek()
+		if token == "" || token == "+" || token == "-" {
+			return nil
+		}
+		p.take()
+
+		if ruled {
+			return fmt.Errorf("'%s': a group of dice takes a single drop, keep or advantage rule", token)
+		}
+		ruled = true
+
+		var err error
+		switch token {
+		case "drop", "keep":
+			var side string
+			switch side = p.take(); side {
+			case "lowest", "highest":
+			default:
+				return fmt.Errorf("expected lowest or highest after '%s', got %s", token, quote(side))
+			}
+			err = p.keepOrDrop(group, token == "keep", side == "highest")
+		case "dl", "dh":
+			err = p.keepOrDrop(group, false, token == "dh")
+		case "kl", "kh":
+			err = p.keepOrDrop(group, true, token == "kh")
+		case "with":
+			switch rule := p.take(); rule {
+			case "advantage":
+				group.Advantage = true
+			case "disadvantage":
+				group.Disadvantage = true
+			default:
+				return fmt.Errorf("expected advantage or disadvantage after 'with', got %s", quote(rule))
+			}
+		case "advantage", "adv":
+			group.Advantage = true
+		case "disadvantage", "dis":
+			group.Disadvantage = true
+		default:
+			return fmt.Errorf("unexpected '%s'", token)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// keepOrDrop reads the number of dice of a keep or drop rule. Keeping the highest
+// dice is the same as dropping the lowest ones.
+func (p *parser) keepOrDrop(group *Group, keep, highest bool) error {
+	n, err := p.optionalNumber(1)
+	if err != nil {
+		return err
+	}
+
+	if keep {
+		if n < 1 || n > group.Count {
+			return fmt.Errorf("cannot keep %d of %d dice", n, group.Count)
+		}
+		group.Drop = group.Count - n
+		group.DropHighest = !highest
+	} else {
+		if n < 1 || n >= group.Count {
+			return fmt.Errorf("cannot drop %d of %d dice", n, group.Count)
+		}
+		group.Drop = n
+		group.DropHighest = highest
+	}
+	return nil
+}
@@ -0,0 +1,362 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"mcp-dungeon/game/dice"
+	"mcp-dungeon/models"
+)
+
+// Engine owns one game: a player and the world it explores.
+// Every method holds the engine lock, so a game can safely receive
+// concurrent tool calls. Values returned by the engine are copies.
+type Engine struct {
+	mu          sync.Mutex
+	player      *models.Player
+	world       *World
+	progression *models.Progression
+	random      *Random
+	changes     uint64
+}
+
+// NewEngine starts a game for a copy of the given player in the given dungeon.
+// Every roll of the game comes from a generator started from seed.
+func NewEngine(dungeon *models.Dungeon, player *models.Player, progression *models.Progression, seed uint64) *Engine {
+	engine := &Engine{
+		player:      ClonePlayer(player),
+		world:       NewWorld(dungeon, nil),
+		progression: progression,
+		random:      NewRandom(seed),
+	}
+	engine.world.Visit(engine.player.CurrentLocation)
+	return engine
+}
+
+// ClonePlayer returns a copy of the player that shares nothing with the original.
+func ClonePlayer(player *models.Player) *models.Player {
+	clone := *player
+	clone.Inventory = slices.Clone(player.Inventory)
+	if player.Weapon != nil {
+		weapon := *player.Weapon
+		clone.Weapon = &weapon
+	}
+	if player.Armor != nil {
+		armor := *player.Armor
+		clone.Armor = &armor
+	}
+	return &clone
+}
+
+// Dungeon returns the static dungeon template of the game.
+func (e *Engine) Dungeon() *models.Dungeon {
+	return e.world.Dungeon
+}
+
+// Changes returns a counter increased by every change made to the game.
+func (e *Engine) Changes() uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.changes
+}
+
+// Player returns a snapshot of the player.
+func (e *Engine) Player() models.Player {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return *ClonePlayer(e.player)
+}
+
+// Events returns up to count of the latest events of the game, oldest first.
+func (e *Engine) Events(count int) []models.Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.world.RecentEvents(count)
+}
+
+// Location returns a location as it currently is in the game.
+func (e *Engine) Location(id string) (models.Location, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.world.Location(id)
+}
+
+// IsObstacle reports whether the cell at the given coordinates cannot be entered.
+func (e *Engine) IsObstacle(x, y int) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return IsObstacle(e.world.Dungeon, x, y)
+}
+
+// LocationAt returns the location found at the given coordinates as it currently is in the game.
+func (e *Engine) LocationAt(x, y int) (models.Location, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.world.LocationAt(x, y)
+}
+
+// Map renders the dungeon map with the player position.
+func (e *Engine) Map() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return GenerateDungeonMap(e.world.Dungeon, e.player)
+}
+
+// Seed returns the seed of the random generator of the game.
+func (e *Engine) Seed() uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.random.seed
+}
+
+// Roll rolls a dice expression with the random generator of the game. Rolling moves
+// the generator forward, so it counts as a change: a save made afterwards replays the
+// next rolls of the game as they would have happened.
+func (e *Engine) Roll(expression dice.Expression) dice.Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.changes++
+	return expression.Roll(e.random)
+}
+
+// Snapshot returns a copy of the player, of the world state and of the state of the
+// random generator, ready to be saved.
+func (e *Engine) Snapshot() (models.Player, models.WorldState, models.RandomState) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return *ClonePlayer(e.player), *CloneWorldState(e.world.State), e.random.State()
+}
+
+// Restore replaces the player, the world state and, when given, the random generator,
+// typically with a loaded save.
+func (e *Engine) Restore(player models.Player, state models.WorldState, random *models.RandomState) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if random != nil {
+		restored, err := RestoreRandom(*random)
+		if err != nil {
+			return err
+		}
+		e.random = restored
+	}
+	e.player = ClonePlayer(&player)
+	e.world.State = CloneWorldState(&state)
+	e.changes++
+	return nil
+}
+
+// currentLocation must be called with the lock held.
+func (e *Engine) currentLocation() (models.Location, error) {
+	location, exists := e.world.Location(e.player.CurrentLocation)
+	if !exists {
+		return location, fmt.Errorf("Current player location '%s' is invalid", e.player.CurrentLocation)
+	}
+	return location, nil
+}
+
+// MoveResult describes a successful move.
+type MoveResult struct {
+	Player      models.Player
+	Location    models.Location
+	ReachedExit bool
+}
+
+// Move takes the player to a room connected to the current one.
+func (e *Engine) Move(targetRoom string) (MoveResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return MoveResult{}, fmt.Errorf("Player %s is dead and cannot move", e.player.Name)
+	}
+
+	targetLocation, exists := e.world.Location(targetRoom)
+	if !exists {
+		return MoveResult{}, fmt.Errorf("Room '%s' does not exist", targetRoom)
+	}
+
+	if e.player.CurrentLocation == targetRoom {
+		return MoveResult{}, fmt.Errorf("Player is already in room '%s'", targetRoom)
+	}
+
+	currentLocation, err := e.currentLocation()
+	if err != nil {
+		return MoveResult{}, err
+	}
+
+	if !slices.Contains(currentLocation.Connections, targetRoom) {
+		return MoveResult{}, fmt.Errorf("Cannot move to '%s' - not connected to current room '%s'", targetRoom, e.player.CurrentLocation)
+	}
+
+	if IsObstacleLocation(e.world.Dungeon, targetLocation) {
+		return MoveResult{}, fmt.Errorf("Cannot move to '%s' - the way is blocked by an obstacle", targetRoom)
+	}
+
+	if IsDoorLocked(currentLocation, targetLocation) {
+		return MoveResult{}, fmt.Errorf("Cannot move to '%s' - the door is locked", targetRoom)
+	}
+
+	fighting := e.world.Fighting(currentLocation.ID)
+	if monster := BlockingMonster(currentLocation, e.player.PreviousLocation, targetRoom, fighting); monster != nil {
+		if fighting {
+			return MoveResult{}, fmt.Errorf("Cannot move to '%s' - %s is fighting you. Defeat it or flee", targetRoom, monster.Name)
+		}
+		return MoveResult{}, fmt.Errorf("Cannot move to '%s' - %s blocks the way. Defeat it or go back to '%s'",
+			targetRoom, monster.Name, e.player.PreviousLocation)
+	}
+
+	return e.enter(targetLocation), nil
+}
+
+// enter takes the player into a location. It must be called with the lock held.
+func (e *Engine) enter(location models.Location) MoveResult {
+	e.player.PreviousLocation = e.player.CurrentLocation
+	e.player.CurrentLocation = location.ID
+	e.player.Coordinates = location.Coordinates
+	e.world.Visit(location.ID)
+	e.changes++
+
+	return MoveResult{
+		Player:      *ClonePlayer(e.player),
+		Location:    location,
+		ReachedExit: location.ID == e.world.Dungeon.ExitRoom,
+	}
+}
+
+// AttackResult describes one combat round against the monster of the current room.
+type AttackResult struct {
+	Player     models.Player
+	Monster    models.Monster
+	Round      CombatRound
+	Experience int
+	LevelUps   []LevelUp
+	Treasure   models.Treasure
+}
+
+// Attack resolves one combat round against the monster of the current room.
+func (e *Engine) Attack() (AttackResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return AttackResult{}, fmt.Errorf("Player %s is dead and cannot fight", e.player.Name)
+	}
+
+	currentLocation, err := e.currentLocation()
+	if err != nil {
+		return AttackResult{}, err
+	}
+
+	if !HasLivingMonster(currentLocation) {
+		return AttackResult{}, fmt.Errorf("There is no living monster in room '%s'", e.player.CurrentLocation)
+	}
+
+	monster := currentLocation.Monster
+	result := AttackResult{Round: ResolveCombatRound(e.random, e.player, monster)}
+	e.world.StartFight(e.player.CurrentLocation)
+	e.world.SetMonsterHitPoints(e.player.CurrentLocation, monster.HitPoints)
+	e.changes++
+
+	if result.Round.MonsterDefeated {
+		result.Treasure = monster.Treasure
+		result.Experience, result.LevelUps = ClaimVictory(e.player, monster, e.progression)
+	}
+
+	result.Player = *ClonePlayer(e.player)
+	result.Monster = *monster
+	return result, nil
+}
+
+// CollectTreasure moves the treasure of the current room to the player.
+func (e *Engine) CollectTreasure() (models.Treasure, models.Player, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return models.Treasure{}, models.Player{}, fmt.Errorf("Player %s is dead and cannot collect treasures", e.player.Name)
+	}
+
+	roomID := e.player.CurrentLocation
+	currentLocation, err := e.currentLocation()
+	if err != nil {
+		return models.Treasure{}, models.Player{}, err
+	}
+
+	if currentLocation.Treasure == nil {
+		return models.Treasure{}, models.Player{}, fmt.Errorf("There is no treasure to collect in room '%s'", roomID)
+	}
+
+	if HasLivingMonster(currentLocation) {
+		return models.Treasure{}, models.Player{}, fmt.Errorf("Cannot collect the treasure - %s is guarding it", currentLocation.Monster.Name)
+	}
+
+	treasure := *currentLocation.Treasure
+	AwardTreasure(e.player, treasure)
+	e.world.TakeTreasure(roomID)
+	e.changes++
+
+	return treasure, *ClonePlayer(e.player), nil
+}
+
+// PickUpItem moves quantity units of an item from the current room to the inventory.
+// A quantity of 0 picks up the whole stack.
+func (e *Engine) PickUpItem(itemType string, quantity int) (models.Item, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return models.Item{}, fmt.Errorf("Player %s is dead and cannot pick up items", e.player.Name)
+	}
+
+	roomID := e.player.CurrentLocation
+	currentLocation, err := e.currentLocation()
+	if err != nil {
+		return models.Item{}, err
+	}
+
+	index := FindItem(currentLocation.Items, itemType)
+	if index < 0 {
+		return models.Item{}, fmt.Errorf("There is no %s in room '%s'", itemType, roomID)
+	}
+
+	item := currentLocation.Items[index]
+	if quantity == 0 {
+		quantity = item.Quantity
+	}
+	if quantity < 0 || quantity > item.Quantity {
+		return models.Item{}, fmt.Errorf("Invalid quantity: there are %d %s in room '%s'", item.Quantity, itemType, roomID)
+	}
+
+	item.Quantity = quantity
+	e.player.Inventory = AddItem(e.player.Inventory, item)
+	e.world.TakeItem(roomID, itemType, quantity)
+	e.changes++
+
+	return item, nil
+}
+
+// UseInventoryItem uses one unit of an item of the inventory and returns the hit points restored.
+func (e *Engine) UseInventoryItem(itemType string) (int, models.Player, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return 0, models.Player{}, fmt.Errorf("Player %s is dead and cannot use items", e.player.Name)
+	}
+
+	index := FindItem(e.player.Inventory, itemType)
+	if index < 0 {
+		return 0, models.Player{}, fmt.Errorf("There is no %s in the inventory of %s", itemType, e.player.Name)
+	}
+
+	healed, err := UseItem(e.player, index)
+	if err != nil {
+		return 0, models.Player{}, fmt.Errorf("Cannot use %s: %v", itemType, err)
+	}
+	e.changes++
+
+	return healed, *ClonePlayer(e.player), nil
+}
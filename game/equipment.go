@@ -0,0 +1,111 @@
+package game
+
+import (
+	"fmt"
+
+	"mcp-dungeon/models"
+)
+
+// equipmentSlot returns the slot of the player holding the items of the given slot name.
+func equipmentSlot(player *models.Player, slot string) (**models.Item, error) {
+	switch slot {
+	case models.ItemSlotWeapon:
+		return &player.Weapon, nil
+	case models.ItemSlotArmor:
+		return &player.Armor, nil
+	}
+	return nil, fmt.Errorf("Unknown equipment slot '%s' (expected one of %v)", slot, models.ItemSlots)
+}
+
+// EffectiveAttackPower is the attack power of the player with the bonuses of its equipment.
+func EffectiveAttackPower(player *models.Player) int {
+	attack := player.AttackPower
+	for _, item := range []*models.Item{player.Weapon, player.Armor} {
+		if item != nil {
+			attack += item.AttackBonus
+		}
+	}
+	return attack
+}
+
+// EffectiveDefense is the defense of the player with the bonuses of its equipment.
+func EffectiveDefense(player *models.Player) int {
+	defense := player.Defense
+	for _, item := range []*models.Item{player.Weapon, player.Armor} {
+		if item != nil {
+			defense += item.DefenseBonus
+		}
+	}
+	return defense
+}
+
+// EquipResult describes an item equipped by the player.
+type EquipResult struct {
+	Item models.Item
+	// Replaced is the item that was in the slot before, back in the inventory
+	Replaced *models.Item
+	Player   models.Player
+}
+
+// EquipItem takes one unit of an item of the inventory and puts it in its equipment slot.
+// The item previously in the slot goes back to the inventory.
+func (e *Engine) EquipItem(itemType string) (EquipResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return EquipResult{}, fmt.Errorf("Player %s is dead and cannot equip items", e.player.Name)
+	}
+
+	index := FindItem(e.player.Inventory, itemType)
+	if index < 0 {
+		return EquipResult{}, fmt.Errorf("There is no %s in the inventory of %s", itemType, e.player.Name)
+	}
+
+	item := e.player.Inventory[index]
+	if item.Slot == "" {
+		return EquipResult{}, fmt.Errorf("%s cannot be equipped", itemType)
+	}
+	slot, err := equipmentSlot(e.player, item.Slot)
+	if err != nil {
+		return EquipResult{}, fmt.Errorf("%s cannot be equipped: %v", itemType, err)
+	}
+
+	item.Quantity = 1
+	e.player.Inventory = RemoveItem(e.player.Inventory, index, 1)
+
+	result := EquipResult{Item: item, Replaced: *slot}
+	if *slot != nil {
+		e.player.Inventory = AddItem(e.player.Inventory, **slot)
+	}
+	*slot = &item
+	e.changes++
+
+	result.Player = *ClonePlayer(e.player)
+	return result, nil
+}
+
+// UnequipItem puts the item of an equipment slot back in the inventory.
+func (e *Engine) UnequipItem(slotName string) (models.Item, models.Player, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return models.Item{}, models.Player{}, fmt.Errorf("Player %s is dead and cannot unequip items", e.player.Name)
+	}
+
+	slot, err := equipmentSlot(e.player, slotName)
+	if err != nil {
+		return models.Item{}, models.Player{}, err
+	}
+	if *slot == nil {
+		return models.Item{}, models.Player{}, fmt.Errorf("%s has no %s equipped", e.player.Name, slotName)
+	}
+
+	item := **slot
+	e.player.Inventory = AddItem(e.player.Inventory, item)
+	*slot = nil
+	e.changes++
+
+	return item, *ClonePlayer(e.player), nil
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"mcp-dungeon/models"
+)
+
+// FleeDifficulty is the base difficulty class of a flee: the player escapes when an
+// agility check reaches FleeDifficulty + half the monster difficulty.
+const FleeDifficulty = 10
+
+// FleeResult describes an attempt to flee from the monster of the current room.
+type FleeResult struct {
+	Check   SkillCheckResult
+	Monster models.Monster
+	// Round is the free hit of the monster when the check failed
+	Round   CombatRound
+	Escaped bool
+	// Move is the retreat to the previous room, when the player escaped
+	Move   MoveResult
+	Player models.Player
+}
+
+// Flee retreats from the monster of the current room to the room the player came from.
+// On a failed agility check the monster gets a free hit before the player escapes,
+// if the player survives it. The monster keeps its hit points for the next encounter.
+func (e *Engine) Flee() (FleeResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return FleeResult{}, fmt.Errorf("Player %s is dead and cannot flee", e.player.Name)
+	}
+
+	roomID := e.player.CurrentLocation
+	currentLocation, err := e.currentLocation()
+	if err != nil {
+		return FleeResult{}, err
+	}
+
+	if !HasLivingMonster(currentLocation) {
+		return FleeResult{}, fmt.Errorf("There is no monster to flee from in room '%s'", roomID)
+	}
+
+	previous := e.player.PreviousLocation
+	previousLocation, exists := e.world.Location(previous)
+	if previous == "" || !exists || !slices.Contains(currentLocation.Connections, previous) {
+		return FleeResult{}, fmt.Errorf("There is no way back to flee from room '%s'", roomID)
+	}
+
+	monster := currentLocation.Monster
+	result := FleeResult{}
+	result.Check, err = e.rollCheck(SkillAgility, FleeDifficulty+monster.DifficultyLevel/2)
+	if err != nil {
+		return FleeResult{}, err
+	}
+	e.logCheck("tried to flee from "+monster.Name, result.Check)
+
+	if !result.Check.Success {
+		result.Round = ResolveStrike(e.random, e.player, monster, 0, true)
+	}
+	e.changes++
+
+	if !result.Round.PlayerDefeated {
+		result.Escaped = true
+		e.world.EndFight(roomID)
+		result.Move = e.enter(previousLocation)
+	}
+
+	result.Player = *ClonePlayer(e.player)
+	result.Monster = *monster
+	return result, nil
+}
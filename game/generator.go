@@ -0,0 +1,314 @@
+package game
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"mcp-dungeon/models"
+)
+
+// GeneratorOptions drives the procedural dungeon generator.
+type GeneratorOptions struct {
+	Name   string
+	Width  int
+	Height int
+	// Density is the share of the grid cells that become rooms or corridors (0 to 1).
+	// The path from the entrance to the exit is always kept, whatever the density.
+	Density float64
+	// CorridorRatio is the share of the locations that are corridors (0 to 1).
+	CorridorRatio float64
+	Monsters      int
+	NPCs          int
+	Treasures     int
+	Seed          uint64
+}
+
+// loopChance is the probability to connect a new location to another neighbour
+// than the one it grew from, which creates loops in the dungeon.
+const loopChance = 0.25
+
+type generator struct {
+	options GeneratorOptions
+	rng     *rand.Rand
+	// cells in the order they were added, connections keyed by cell
+	cells       [][2]int
+	connections map[[2]int][][2]int
+}
+
+// GenerateDungeon builds a random dungeon. The same options (seed included) always give
+// the same dungeon. The exit can always be reached from the entrance, and every location
+// follows the rules of specs/specs.en.md.
+func GenerateDungeon(options GeneratorOptions) (*models.Dungeon, error) {
+	if options.Width <= 0 || options.Height <= 0 || options.Width*options.Height < 2 {
+		return nil, fmt.Errorf("the dungeon must have at least 2 cells, got %dx%d", options.Width, options.Height)
+	}
+	if options.Density < 0 || options.Density > 1 {
+		return nil, fmt.Errorf("density %.2f is out of range [0, 1]", options.Density)
+	}
+	if options.CorridorRatio < 0 || options.CorridorRatio > 1 {
+		return nil, fmt.Errorf("corridor ratio %.2f is out of range [0, 1]", options.CorridorRatio)
+	}
+	if options.Monsters < 0 || options.NPCs < 0 || options.Treasures < 0 {
+		return nil, fmt.Errorf("monster, NPC and treasure counts cannot be negative")
+	}
+
+	g := &generator{
+		options:     options,
+		rng:         rand.New(rand.NewPCG(options.Seed, options.Seed)),
+		connections: map[[2]int][][2]int{},
+	}
+
+	entrance, exit := g.carvePath()
+	g.grow()
+
+	return g.dungeon(entrance, exit)
+}
+
+func (g *generator) randomCell() [2]int {
+	return [2]int{g.rng.IntN(g.options.Width), g.rng.IntN(g.options.Height)}
+}
+
+func (g *generator) inside(cell [2]int) bool {
+	return cell[0] >= 0 && cell[0] < g.options.Width && cell[1] >= 0 && cell[1] < g.options.Height
+}
+
+func (g *generator) add(cell [2]int) {
+	g.cells = append(g.cells, cell)
+	g.connections[cell] = nil
+}
+
+func (g *generator) connect(a, b [2]int) {
+	if !slices.Contains(g.connections[a], b) {
+		g.connections[a] = append(g.connections[a], b)
+		g.connections[b] = append(g.connections[b], a)
+	}
+}
+
+func neighbours(cell [2]int) [][2]int {
+	x, y := cell[0], cell[1]
+	return [][2]int{{x, y - 1}, {x + 1, y}, {x, y + 1}, {x - 1, y}}
+}
+
+// carvePath picks the entrance and the exit, and connects them with a walk that
+// always gets closer to the exit.
+func (g *generator) carvePath() (entrance, exit [2]int) {
+	entrance = g.randomCell()
+	exit = g.randomCell()
+	for exit == entrance {
+		exit = g.randomCell()
+	}
+
+	g.add(entrance)
+	current := entrance
+	for current != exit {
+		next := current
+		dx, dy := sign(exit[0]-current[0]), sign(exit[1]-current[1])
+		if dx != 0 && (dy == 0 || g.rng.IntN(2) == 0) {
+			next[0] += dx
+		} else {
+			next[1] += dy
+		}
+		g.add(next)
+		g.connect(current, next)
+		current = next
+	}
+	return entrance, exit
+}
+
+// grow adds locations next to the existing ones until the density is reached.
+func (g *generator) grow() {
+	target := int(g.options.Density*float64(g.options.Width*g.options.Height) + 0.5)
+
+	for len(g.cells) < target {
+		var candidates [][2][2]int // {new cell, existing neighbour}
+		for _, cell := range g.cells {
+			for _, next := range neighbours(cell) {
+				if _, used := g.connections[next]; g.inside(next) && !used {
+					candidates = append(candidates, [2][2]int{next, cell})
+				}
+			}
+		}
+		if len(candidates) == 0 {
+			return
+		}
+
+		candidate := candidates[g.rng.IntN(len(candidates))]
+		cell := candidate[0]
+		g.add(cell)
+		g.connect(cell, candidate[1])
+
+		for _, next := range neighbours(cell) {
+			if _, used := g.connections[next]; used && next != candidate[1] && g.rng.Float64() < loopChance {
+				g.connect(cell, next)
+			}
+		}
+	}
+}
+
+func (g *generator) dungeon(entrance, exit [2]int) (*models.Dungeon, error) {
+	options := g.options
+
+	// Pick the corridors, the entrance and the exit are always rooms
+	corridors := map[[2]int]bool{}
+	for _, cell := range g.cells {
+		if cell != entrance && cell != exit && g.rng.Float64() < options.CorridorRatio {
+			corridors[cell] = true
+		}
+	}
+
+	// Monsters, NPCs and treasures are placed in rooms, never at the entrance
+	var rooms [][2]int
+	for _, cell := range g.cells {
+		if cell != entrance && !corridors[cell] {
+			rooms = append(rooms, cell)
+		}
+	}
+	needed := max(options.Monsters+options.NPCs, options.Treasures)
+	for _, cell := range g.cells {
+		if len(rooms) >= needed {
+			break
+		}
+		if corridors[cell] {
+			delete(corridors, cell)
+			rooms = append(rooms, cell)
+		}
+	}
+	if len(rooms) < needed {
+		return nil, fmt.Errorf("a %dx%d dungeon with density %.2f has %d rooms, not enough for %d monsters, %d NPCs and %d treasures",
+			options.Width, options.Height, options.Density, len(rooms), options.Monsters, options.NPCs, options.Treasures)
+	}
+
+	// Ids are given in reading order
+	ordered := slices.Clone(g.cells)
+	slices.SortFunc(ordered, func(a, b [2]int) int {
+		if a[1] != b[1] {
+			return a[1] - b[1]
+		}
+		return a[0] - b[0]
+	})
+	ids := map[[2]int]string{entrance: "entrance", exit: "exit"}
+	roomCount, corridorCount := 0, 0
+	for _, cell := range ordered {
+		switch {
+		case cell == entrance || cell == exit:
+		case corridors[cell]:
+			corridorCount++
+			ids[cell] = fmt.Sprintf("corridor_%d", corridorCount)
+		default:
+			roomCount++
+			ids[cell] = fmt.Sprintf("room_%d", roomCount)
+		}
+	}
+
+	dungeon := &models.Dungeon{
+		Name:         options.Name,
+		Description:  fmt.Sprintf("A procedurally generated dungeon (seed %d)", options.Seed),
+		Size:         models.Size{Width: options.Width, Height: options.Height},
+		EntranceRoom: ids[entrance],
+		ExitRoom:     ids[exit],
+		Locations:    map[string]models.Location{},
+	}
+
+	for _, cell := range ordered {
+		locationType := "room"
+		if corridors[cell] {
+			locationType = "corridor"
+		}
+
+		var connections []string
+		for _, next := range neighbours(cell) {
+			if slices.Contains(g.connections[cell], next) {
+				connections = append(connections, ids[next])
+			}
+		}
+
+		dungeon.Locations[ids[cell]] = models.Location{
+			ID:          ids[cell],
+			Type:        locationType,
+			Coordinates: cell,
+			Description: fmt.Sprintf("A %s at [%d, %d]", locationType, cell[0], cell[1]),
+			Connections: connections,
+		}
+	}
+
+	// Monsters and NPCs never share a room
+	g.rng.Shuffle(len(rooms), func(i, j int) { rooms[i], rooms[j] = rooms[j], rooms[i] })
+	for i, cell := range rooms[:options.Monsters+options.NPCs] {
+		location := dungeon.Locations[ids[cell]]
+		if i < options.Monsters {
+			location.Monster = g.monster()
+		} else {
+			location.NPC = g.npc()
+		}
+		dungeon.Locations[ids[cell]] = location
+	}
+
+	g.rng.Shuffle(len(rooms), func(i, j int) { rooms[i], rooms[j] = rooms[j], rooms[i] })
+	for _, cell := range rooms[:options.Treasures] {
+		location := dungeon.Locations[ids[cell]]
+		treasure := g.treasure()
+		location.Treasure = &treasure
+		dungeon.Locations[ids[cell]] = location
+	}
+
+	return dungeon, nil
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rng.IntN(len(values))]
+}
+
+func (g *generator) between(minValue, maxValue int) int {
+	return minValue + g.rng.IntN(maxValue-minValue+1)
+}
+
+func (g *generator) monster() *models.Monster {
+	monsterType := g.pick(models.MonsterTypes)
+	difficulty := g.between(models.MinDifficultyLevel, models.MaxDifficultyLevel)
+	return &models.Monster{
+		Type:            monsterType,
+		Name:            capitalize(monsterType),
+		Description:     fmt.Sprintf("A %s of difficulty %d", monsterType, difficulty),
+		DifficultyLevel: difficulty,
+		HitPoints:       min(models.MaxMonsterHitPoints, difficulty*10),
+		Treasure: models.Treasure{
+			Type:  "gold",
+			Value: min(models.MaxTreasureValue, difficulty*g.between(5, 20)),
+		},
+	}
+}
+
+func (g *generator) npc() *models.NPC {
+	npcType := g.pick(models.NPCTypes)
+	return &models.NPC{
+		Type:        npcType,
+		Name:        capitalize(npcType),
+		Description: fmt.Sprintf("A wandering %s", npcType),
+	}
+}
+
+func (g *generator) treasure() models.Treasure {
+	return models.Treasure{
+		Type:  g.pick(models.TreasureTypes),
+		Value: g.between(models.MinTreasureValue, models.MaxTreasureValue),
+	}
+}
+
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+func sign(value int) int {
+	switch {
+	case value > 0:
+		return 1
+	case value < 0:
+		return -1
+	}
+	return 0
+}
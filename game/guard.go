@@ -0,0 +1,32 @@
+package game
+
+import (
+	"mcp-dungeon/models"
+)
+
+// GuardBlockExits makes the room's monster block every exit except the one
+// the player came in by, until the monster is defeated.
+const GuardBlockExits = "block_exits"
+
+// BlockingMonster returns the monster preventing the player from leaving
+// location towards target, or nil if the way is free. A monster in the middle
+// of a fight blocks every exit, whatever its guard: the player has to flee.
+func BlockingMonster(location models.Location, previous, target string, fighting bool) *models.Monster {
+	if !HasLivingMonster(location) {
+		return nil
+	}
+
+	if fighting {
+		return location.Monster
+	}
+
+	if location.Guard != GuardBlockExits {
+		return nil
+	}
+
+	if target == previous {
+		return nil
+	}
+
+	return location.Monster
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"fmt"
+
+	"mcp-dungeon/models"
+)
+
+// NPCTypeHealer is the type of the NPCs who heal the player.
+const NPCTypeHealer = "healer"
+
+// HealingResult describes the healing given by a healer.
+type HealingResult struct {
+	Player models.Player
+	Healer models.NPC
+	Healed int
+	Fee    int
+}
+
+// RequestHealing asks the healer of the current room to restore the player to full health,
+// for its healing fee. A healer heals the player once per visit of its room.
+func (e *Engine) RequestHealing() (HealingResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return HealingResult{}, fmt.Errorf("Player %s is dead and cannot be healed", e.player.Name)
+	}
+
+	location, err := e.currentLocation()
+	if err != nil {
+		return HealingResult{}, err
+	}
+	if location.NPC == nil || location.NPC.Type != NPCTypeHealer {
+		return HealingResult{}, fmt.Errorf("There is no healer in room '%s'", location.ID)
+	}
+	healer := *location.NPC
+
+	if e.player.HitPoints >= e.player.MaxHitPoints && e.player.Status == StatusHealthy {
+		return HealingResult{}, fmt.Errorf("%s is already at full health", e.player.Name)
+	}
+
+	if e.world.HealedDuringVisit(location.ID) {
+		return HealingResult{}, fmt.Errorf("%s already healed %s during this visit, leave the room and come back later",
+			healer.Name, e.player.Name)
+	}
+
+	if healer.HealingFee > e.player.Gold {
+		return HealingResult{}, fmt.Errorf("Not enough gold: %s asks %d gold for healing, %s has %d",
+			healer.Name, healer.HealingFee, e.player.Name, e.player.Gold)
+	}
+
+	healed := e.player.MaxHitPoints - e.player.HitPoints
+	e.player.Gold -= healer.HealingFee
+	e.player.HitPoints = e.player.MaxHitPoints
+	UpdatePlayerStatus(e.player)
+	e.world.Heal(location.ID)
+	e.changes++
+
+	return HealingResult{Player: *ClonePlayer(e.player), Healer: healer, Healed: healed, Fee: healer.HealingFee}, nil
+}
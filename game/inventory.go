@@ -0,0 +1,61 @@
+package game
+
+import (
+	"fmt"
+
+	"mcp-dungeon/models"
+)
+
+// FindItem returns the index of the first stack of the given type, or -1.
+func FindItem(items []models.Item, itemType string) int {
+	for i, item := range items {
+		if item.Type == itemType {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddItem puts an item into an inventory, merging it with an identical stack if any.
+func AddItem(inventory []models.Item, item models.Item) []models.Item {
+	for i, existing := range inventory {
+		existing.Quantity = item.Quantity
+		if existing == item {
+			inventory[i].Quantity += item.Quantity
+			return inventory
+		}
+	}
+	return append(inventory, item)
+}
+
+// RemoveItem takes quantity units from the stack at index and drops the stack once empty.
+func RemoveItem(items []models.Item, index, quantity int) []models.Item {
+	items[index].Quantity -= quantity
+	if items[index].Quantity <= 0 {
+		return append(items[:index:index], items[index+1:]...)
+	}
+	return items
+}
+
+// UseItem consumes one unit of the inventory stack at index and returns the hit points restored.
+func UseItem(player *models.Player, index int) (int, error) {
+	item := player.Inventory[index]
+
+	if item.Slot != "" {
+		return 0, fmt.Errorf("%s cannot be used, equip it instead", item.Type)
+	}
+	if item.HealingLevel <= 0 {
+		return 0, fmt.Errorf("%s cannot be used", item.Type)
+	}
+
+	if player.HitPoints >= player.MaxHitPoints {
+		return 0, fmt.Errorf("%s is already at full health", player.Name)
+	}
+
+	healed := min(item.HealingLevel, player.MaxHitPoints-player.HitPoints)
+	player.HitPoints += healed
+	player.Inventory = RemoveItem(player.Inventory, index, 1)
+	UpdatePlayerStatus(player)
+
+	return healed, nil
+}
@@ -14,11 +14,20 @@ func GenerateVisualMap(dungeon *models.Dungeon, player *models.Player) string {
 		}
 	}
 
+	for _, obstacle := range dungeon.Obstacles {
+		x, y := obstacle[0], obstacle[1]
+		if x >= 0 && x < dungeon.Size.Width && y >= 0 && y < dungeon.Size.Height {
+			grid[y][x] = "###"
+		}
+	}
+
 	for _, location := range dungeon.Locations {
 		x, y := location.Coordinates[0], location.Coordinates[1]
 		if x >= 0 && x < dungeon.Size.Width && y >= 0 && y < dungeon.Size.Height {
 			symbol := " . "
 			switch {
+			case location.Type == models.LocationTypeObstacle:
+				symbol = "###"
 			case location.ID == dungeon.EntranceRoom:
 				symbol = "[E]"
 			case location.ID == dungeon.ExitRoom:
@@ -64,7 +73,7 @@ func GenerateVisualMap(dungeon *models.Dungeon, player *models.Player) string {
 	result += "```\n\n"
 
 	result += "## Legend\n\n"
-	result += "- [R] = Room [C] = Corridor [E] = Entrance [X] = Exit\n"
+	result += "- [R] = Room [C] = Corridor [E] = Entrance [X] = Exit ### = Obstacle\n"
 	result += "- {P} = Player position\n\n"
 
 	result += fmt.Sprintf("Player: %s\n", player.Name)
@@ -0,0 +1,158 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"mcp-dungeon/models"
+)
+
+// NPCTypeMerchant is the type of the NPCs who trade with the player.
+const NPCTypeMerchant = "merchant"
+
+// FindWare returns the index of the first ware of the given type, or -1.
+func FindWare(stock []models.Ware, itemType string) int {
+	for i, ware := range stock {
+		if ware.Type == itemType {
+			return i
+		}
+	}
+	return -1
+}
+
+// SellPrice is the gold a merchant pays for one unit of an item: its value for
+// valuables like gems, or half the price of the same ware in the merchant stock.
+// A merchant does not buy items it cannot price.
+func SellPrice(npc models.NPC, item models.Item) (int, bool) {
+	if item.Value > 0 {
+		return item.Value, true
+	}
+	if index := FindWare(npc.Stock, item.Type); index >= 0 {
+		return max(1, npc.Stock[index].Price/2), true
+	}
+	return 0, false
+}
+
+// TradeResult describes a purchase or a sale.
+type TradeResult struct {
+	Player   models.Player
+	Merchant models.NPC
+	Item     models.Item
+	Gold     int
+}
+
+// currentMerchant must be called with the lock held.
+func (e *Engine) currentMerchant() (models.NPC, error) {
+	location, err := e.currentLocation()
+	if err != nil {
+		return models.NPC{}, err
+	}
+	if location.NPC == nil || location.NPC.Type != NPCTypeMerchant {
+		return models.NPC{}, fmt.Errorf("There is no merchant in room '%s'", location.ID)
+	}
+	return *location.NPC, nil
+}
+
+// MerchantWares returns the merchant of the current room, with its current stock.
+func (e *Engine) MerchantWares() (models.NPC, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.currentMerchant()
+}
+
+// BuyItem buys quantity units of a ware from the merchant of the current room.
+func (e *Engine) BuyItem(itemType string, quantity int) (TradeResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return TradeResult{}, fmt.Errorf("Player %s is dead and cannot trade", e.player.Name)
+	}
+
+	merchant, err := e.currentMerchant()
+	if err != nil {
+		return TradeResult{}, err
+	}
+
+	index := FindWare(merchant.Stock, itemType)
+	if index < 0 {
+		return TradeResult{}, fmt.Errorf("%s does not sell %s", merchant.Name, itemType)
+	}
+
+	ware := merchant.Stock[index]
+	if ware.Quantity == 0 {
+		return TradeResult{}, fmt.Errorf("%s has no %s left", merchant.Name, itemType)
+	}
+	if quantity <= 0 || quantity > ware.Quantity {
+		return TradeResult{}, fmt.Errorf("Invalid quantity: %s has %d %s", merchant.Name, ware.Quantity, itemType)
+	}
+
+	cost := ware.Price * quantity
+	if cost > e.player.Gold {
+		return TradeResult{}, fmt.Errorf("Not enough gold: %d %s cost %d gold, %s has %d",
+			quantity, itemType, cost, e.player.Name, e.player.Gold)
+	}
+
+	item := ware.Item
+	item.Quantity = quantity
+	e.player.Gold -= cost
+	e.player.Inventory = AddItem(e.player.Inventory, item)
+
+	// Sold out wares stay in the stock so that the merchant still knows their price
+	stock := slices.Clone(merchant.Stock)
+	stock[index].Quantity -= quantity
+	merchant.Stock = stock
+	e.world.SetMerchantStock(e.player.CurrentLocation, stock)
+	e.changes++
+
+	return TradeResult{Player: *ClonePlayer(e.player), Merchant: merchant, Item: item, Gold: cost}, nil
+}
+
+// SellItem sells quantity units of an inventory item to the merchant of the current room.
+func (e *Engine) SellItem(itemType string, quantity int) (TradeResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return TradeResult{}, fmt.Errorf("Player %s is dead and cannot trade", e.player.Name)
+	}
+
+	merchant, err := e.currentMerchant()
+	if err != nil {
+		return TradeResult{}, err
+	}
+
+	index := FindItem(e.player.Inventory, itemType)
+	if index < 0 {
+		return TradeResult{}, fmt.Errorf("There is no %s in the inventory of %s", itemType, e.player.Name)
+	}
+
+	item := e.player.Inventory[index]
+	if quantity <= 0 || quantity > item.Quantity {
+		return TradeResult{}, fmt.Errorf("Invalid quantity: %s has %d %s", e.player.Name, item.Quantity, itemType)
+	}
+
+	price, buys := SellPrice(merchant, item)
+	if !buys {
+		return TradeResult{}, fmt.Errorf("%s does not buy %s", merchant.Name, itemType)
+	}
+
+	gold := price * quantity
+	item.Quantity = quantity
+	e.player.Gold += gold
+	e.player.Inventory = RemoveItem(e.player.Inventory, index, quantity)
+
+	// The merchant sells the item back for twice what it paid, unless it already has a price for it
+	stock := slices.Clone(merchant.Stock)
+	if wareIndex := FindWare(stock, itemType); wareIndex >= 0 && stock[wareIndex].Item.HealingLevel == item.HealingLevel &&
+		stock[wareIndex].Item.Value == item.Value {
+		stock[wareIndex].Quantity += quantity
+	} else {
+		stock = append(stock, models.Ware{Item: item, Price: price * 2})
+	}
+	merchant.Stock = stock
+	e.world.SetMerchantStock(e.player.CurrentLocation, stock)
+	e.changes++
+
+	return TradeResult{Player: *ClonePlayer(e.player), Merchant: merchant, Item: item, Gold: gold}, nil
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"fmt"
+
+	"mcp-dungeon/models"
+)
+
+// CannedAnswer returns the answer of an NPC for a turn of a conversation without
+// a chat model: the dialogue lines of the NPC are said in turn.
+func CannedAnswer(npc models.NPC, turn int) string {
+	if len(npc.Dialogue) == 0 {
+		return fmt.Sprintf("*%s looks at you silently*", npc.Name)
+	}
+	return npc.Dialogue[turn%len(npc.Dialogue)]
+}
+
+// CurrentNPC returns the NPC of the current room and the room itself.
+// A dead player cannot talk to anyone.
+func (e *Engine) CurrentNPC() (models.NPC, models.Location, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) {
+		return models.NPC{}, models.Location{}, fmt.Errorf("Player %s is dead and cannot talk", e.player.Name)
+	}
+
+	location, err := e.currentLocation()
+	if err != nil {
+		return models.NPC{}, location, err
+	}
+	if location.NPC == nil {
+		return models.NPC{}, location, fmt.Errorf("There is no NPC in room '%s'", location.ID)
+	}
+	return *location.NPC, location, nil
+}
+
+// TalkedToNPC rewards the player for the first conversation with the NPC of a location,
+// and returns the experience earned and the levels gained. A clever player learns more
+// from the conversation: the intelligence modifier is added to the experience.
+// A dead player earns nothing and the NPC is not met.
+func (e *Engine) TalkedToNPC(id string) (int, []LevelUp) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if IsDead(e.player) || !e.world.MeetNPC(id) {
+		return 0, nil
+	}
+
+	experience := e.progression.NPCExperience
+	if experience > 0 {
+		experience = max(1, experience+SkillModifier(e.player.Intelligence))
+	}
+	levelUps := GainExperience(e.player, e.progression, experience)
+	e.changes++
+	return experience, levelUps
+}
@@ -0,0 +1,26 @@
+package game
+
+import (
+	"slices"
+
+	"mcp-dungeon/models"
+)
+
+// IsObstacle reports whether the cell at the given coordinates cannot be entered,
+// either because it is listed in the dungeon obstacles or holds an obstacle location.
+func IsObstacle(dungeon *models.Dungeon, x, y int) bool {
+	if slices.Contains(dungeon.Obstacles, [2]int{x, y}) {
+		return true
+	}
+	for _, location := range dungeon.Locations {
+		if location.Type == models.LocationTypeObstacle && location.Coordinates == [2]int{x, y} {
+			return true
+		}
+	}
+	return false
+}
+
+// IsObstacleLocation reports whether a location cannot be entered.
+func IsObstacleLocation(dungeon *models.Dungeon, location models.Location) bool {
+	return IsObstacle(dungeon, location.Coordinates[0], location.Coordinates[1])
+}
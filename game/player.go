@@ -0,0 +1,51 @@
+package game
+
+import (
+	"mcp-dungeon/models"
+)
+
+const (
+	StatusHealthy  = "healthy"
+	StatusWounded  = "wounded"
+	StatusCritical = "critical"
+	StatusDead     = "dead"
+)
+
+// UpdatePlayerStatus derives the player status from the remaining hit points.
+func UpdatePlayerStatus(player *models.Player) {
+	switch {
+	case player.HitPoints <= 0:
+		player.HitPoints = 0
+		player.Status = StatusDead
+	case player.MaxHitPoints > 0 && player.HitPoints*4 <= player.MaxHitPoints:
+		player.Status = StatusCritical
+	case player.HitPoints < player.MaxHitPoints:
+		player.Status = StatusWounded
+	default:
+		player.Status = StatusHealthy
+	}
+}
+
+// IsDead reports whether the player can no longer act.
+func IsDead(player *models.Player) bool {
+	return player.HitPoints <= 0
+}
+
+// AwardTreasure gives a treasure to the player: gold goes to the purse,
+// gems and artifacts join the inventory, stacked with the identical items already there.
+func AwardTreasure(player *models.Player, treasure models.Treasure) {
+	if treasure.Type == "" || treasure.Value <= 0 {
+		return
+	}
+
+	if treasure.Type == "gold" {
+		player.Gold += treasure.Value
+		return
+	}
+
+	player.Inventory = AddItem(player.Inventory, models.Item{
+		Type:     treasure.Type,
+		Value:    treasure.Value,
+		Quantity: 1,
+	})
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"math"
+
+	"mcp-dungeon/models"
+)
+
+// DefaultProgression is used when no progression file is given.
+func DefaultProgression() models.Progression {
+	return models.Progression{
+		ExperienceBase:    100,
+		ExperienceGrowth:  1.5,
+		MaxLevel:          100,
+		MonsterExperience: 10,
+		NPCExperience:     5,
+	}
+}
+
+// LevelUp describes a level gained by the player and the stats it brought.
+type LevelUp struct {
+	Level  int
+	Growth models.StatGrowth
+}
+
+// ExperienceForLevel returns the total experience needed to reach a level.
+// A steep curve saturates at math.MaxInt instead of overflowing.
+func ExperienceForLevel(progression *models.Progression, level int) int {
+	total := 0.0
+	for l := 1; l < level; l++ {
+		total += float64(progression.ExperienceBase) * math.Pow(progression.ExperienceGrowth, float64(l-1))
+	}
+	total = math.Round(total)
+	if math.IsNaN(total) || total >= math.MaxInt {
+		return math.MaxInt
+	}
+	return int(total)
+}
+
+// ClassGrowth returns the stat growth per level of a player class: the growth of the
+// progression file if it sets one for the class, or the growth of the class registry.
+func ClassGrowth(progression *models.Progression, class string) models.StatGrowth {
+	if growth, exists := progression.Growth[class]; exists {
+		return growth
+	}
+	return Classes[class].Growth
+}
+
+// GainExperience gives experience to the player and applies the level ups it brings.
+// Each level up raises the maximum hit points, attack power and defense of the player
+// by the growth of its class, and heals the player by the hit points gained. A dead
+// player still levels up but is not healed: experience does not bring anyone back.
+func GainExperience(player *models.Player, progression *models.Progression, experience int) []LevelUp {
+	player.Experience += experience
+
+	var levelUps []LevelUp
+	for player.Level < progression.MaxLevel {
+		// A broken curve with a threshold below 1 would level the player up for free
+		threshold := ExperienceForLevel(progression, player.Level+1)
+		if threshold <= 0 || player.Experience < threshold {
+			break
+		}
+
+		growth := ClassGrowth(progression, player.Type)
+		player.Level++
+		player.MaxHitPoints += growth.MaxHitPoints
+		if !IsDead(player) {
+			player.HitPoints += growth.MaxHitPoints
+		}
+		player.AttackPower += growth.AttackPower
+		player.Defense += growth.Defense
+		levelUps = append(levelUps, LevelUp{Level: player.Level, Growth: growth})
+	}
+	if len(levelUps) > 0 {
+		UpdatePlayerStatus(player)
+	}
+	return levelUps
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+
+	"mcp-dungeon/game/dice"
+	"mcp-dungeon/models"
+)
+
+// Random is the random generator of a game. Every roll of the game goes through it,
+// so the same seed and the same actions always give the same game. Its state can be
+// saved, so that a loaded game goes on with the same rolls.
+type Random struct {
+	seed uint64
+	pcg  *rand.PCG
+	rng  *rand.Rand
+}
+
+var _ dice.Source = (*Random)(nil)
+
+// NewRandom returns a generator started from a seed.
+func NewRandom(seed uint64) *Random {
+	pcg := rand.NewPCG(seed, seed)
+	return &Random{seed: seed, pcg: pcg, rng: rand.New(pcg)}
+}
+
+// NewSeed picks a random seed for a game started without one.
+func NewSeed() uint64 {
+	return rand.Uint64()
+}
+
+// IntN returns a number in [0, n).
+func (r *Random) IntN(n int) int {
+	return r.rng.IntN(n)
+}
+
+// State returns the seed of the generator and where it is in its sequence.
+func (r *Random) State() models.RandomState {
+	// PCG.MarshalBinary never fails
+	data, _ := r.pcg.MarshalBinary()
+	return models.RandomState{Seed: r.seed, State: hex.EncodeToString(data)}
+}
+
+// RestoreRandom returns a generator at a saved state. A state without position
+// restarts the sequence of its seed.
+func RestoreRandom(state models.RandomState) (*Random, error) {
+	random := NewRandom(state.Seed)
+	if state.State == "" {
+		return random, nil
+	}
+
+	data, err := hex.DecodeString(state.State)
+	if err != nil {
+		return nil, fmt.Errorf("invalid random state: %v", err)
+	}
+	if err := random.pcg.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("invalid random state: %v", err)
+	}
+	return random, nil
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"fmt"
+
+	"mcp-dungeon/models"
+)
+
+// Skill names, as used in the player files and by skill checks.
+const (
+	SkillStrength     = "strength"
+	SkillAgility      = "agility"
+	SkillIntelligence = "intelligence"
+)
+
+// SkillNames lists the skills of a player.
+var SkillNames = []string{SkillStrength, SkillAgility, SkillIntelligence}
+
+// SkillModifier is the bonus (or malus) given by a skill score: +1 every 2 points
+// above 10, -1 every 2 points below.
+func SkillModifier(score int) int {
+	if score < 10 {
+		return (score - 11) / 2
+	}
+	return (score - 10) / 2
+}
+
+// PlayerSkill returns the score of a skill of the player.
+func PlayerSkill(player *models.Player, skill string) (int, bool) {
+	switch skill {
+	case SkillStrength:
+		return player.Strength, true
+	case SkillAgility:
+		return player.Agility, true
+	case SkillIntelligence:
+		return player.Intelligence, true
+	}
+	return 0, false
+}
+
+// ApplyClassSkills gives the skills of its class to a player whose file predates
+// skills, and checks that every skill is in range.
+func ApplyClassSkills(player *models.Player) error {
+	class, err := LookupClass(player.Type)
+	if err != nil {
+		return err
+	}
+	if player.Skills == (models.Skills{}) {
+		player.Skills = class.Skills
+	}
+	for _, skill := range SkillNames {
+		if score, _ := PlayerSkill(player, skill); score < models.MinSkill || score > models.MaxSkill {
+			return fmt.Errorf("%s %d is out of range [%d, %d]", skill, score, models.MinSkill, models.MaxSkill)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package game
+
+import (
+	"maps"
+	"slices"
+
+	"mcp-dungeon/models"
+)
+
+// World is a dungeon template seen through the runtime state of a game.
+// The template is never modified: every change is recorded in State.
+type World struct {
+	Dungeon *models.Dungeon
+	State   *models.WorldState
+}
+
+func NewWorld(dungeon *models.Dungeon, state *models.WorldState) *World {
+	if state == nil {
+		state = &models.WorldState{}
+	}
+	return &World{Dungeon: dungeon, State: state}
+}
+
+// Location returns the location with the world state applied.
+// The returned value can be modified freely without touching the template.
+func (w *World) Location(id string) (models.Location, bool) {
+	location, exists := w.Dungeon.Locations[id]
+	if !exists {
+		return location, false
+	}
+
+	if location.Monster != nil {
+		monster := *location.Monster
+		if hitPoints, damaged := w.State.MonsterHitPoints[id]; damaged {
+			monster.HitPoints = hitPoints
+		}
+		if slices.Contains(w.State.DefeatedMonsters, id) {
+			monster.HitPoints = 0
+		}
+		if slices.Contains(w.State.StolenTreasures, id) {
+			monster.Treasure = models.Treasure{}
+		}
+		location.Monster = &monster
+	}
+
+	if slices.Contains(w.State.TakenTreasures, id) {
+		location.Treasure = nil
+	}
+
+	location.Items = w.remainingItems(id, location.Items)
+
+	location.LockedDoors = slices.DeleteFunc(slices.Clone(location.LockedDoors), func(target string) bool {
+		return w.IsDoorOpen(id, target)
+	})
+	location.Checks = slices.DeleteFunc(slices.Clone(location.Checks), func(check models.Check) bool {
+		return slices.Contains(w.State.PassedChecks, CheckID(id, check.Name))
+	})
+
+	if stock, traded := w.State.MerchantStocks[id]; traded && location.NPC != nil {
+		npc := *location.NPC
+		npc.Stock = slices.Clone(stock)
+		location.NPC = &npc
+	}
+
+	return location, true
+}
+
+// LocationAt returns the location found at the given coordinates with the world state applied.
+func (w *World) LocationAt(x, y int) (models.Location, bool) {
+	for id, location := range w.Dungeon.Locations {
+		if location.Coordinates[0] == x && location.Coordinates[1] == y {
+			return w.Location(id)
+		}
+	}
+	return models.Location{}, false
+}
+
+func (w *World) remainingItems(id string, items []models.Item) []models.Item {
+	consumed := w.State.ConsumedItems[id]
+	if len(items) == 0 || len(consumed) == 0 {
+		return items
+	}
+
+	taken := make(map[string]int, len(consumed))
+	for itemType, quantity := range consumed {
+		taken[itemType] = quantity
+	}
+
+	var remaining []models.Item
+	for _, item := range items {
+		used := min(item.Quantity, taken[item.Type])
+		taken[item.Type] -= used
+		item.Quantity -= used
+		if item.Quantity > 0 {
+			remaining = append(remaining, item)
+		}
+	}
+	return remaining
+}
+
+// SetMonsterHitPoints records the remaining hit points of the monster of a location.
+// A monster reaching 0 hit points is marked as defeated.
+func (w *World) SetMonsterHitPoints(id string, hitPoints int) {
+	if hitPoints <= 0 {
+		delete(w.State.MonsterHitPoints, id)
+		w.EndFight(id)
+		if !slices.Contains(w.State.DefeatedMonsters, id) {
+			w.State.DefeatedMonsters = append(w.State.DefeatedMonsters, id)
+		}
+		return
+	}
+
+	if w.State.MonsterHitPoints == nil {
+		w.State.MonsterHitPoints = map[string]int{}
+	}
+	w.State.MonsterHitPoints[id] = hitPoints
+}
+
+// StartFight records that the monster of a location is fighting the player.
+func (w *World) StartFight(id string) {
+	if !slices.Contains(w.State.Fights, id) {
+		w.State.Fights = append(w.State.Fights, id)
+	}
+}
+
+// EndFight records that the fight with the monster of a location is over,
+// because the monster was defeated or the player fled.
+func (w *World) EndFight(id string) {
+	w.State.Fights = slices.DeleteFunc(w.State.Fights, func(fight string) bool { return fight == id })
+}
+
+// Fighting reports whether the monster of a location is fighting the player.
+func (w *World) Fighting(id string) bool {
+	return slices.Contains(w.State.Fights, id)
+}
+
+// TakeTreasure marks the treasure of a location as collected.
+func (w *World) TakeTreasure(id string) {
+	if !slices.Contains(w.State.TakenTreasures, id) {
+		w.State.TakenTreasures = append(w.State.TakenTreasures, id)
+	}
+}
+
+// TakeItem records that quantity units of an item type were removed from a location.
+func (w *World) TakeItem(id, itemType string, quantity int) {
+	if w.State.ConsumedItems == nil {
+		w.State.ConsumedItems = map[string]map[string]int{}
+	}
+	if w.State.ConsumedItems[id] == nil {
+		w.State.ConsumedItems[id] = map[string]int{}
+	}
+	w.State.ConsumedItems[id][itemType] += quantity
+}
+
+// SetMerchantStock records the stock of the merchant of a location after a trade.
+func (w *World) SetMerchantStock(id string, stock []models.Ware) {
+	if w.State.MerchantStocks == nil {
+		w.State.MerchantStocks = map[string][]models.Ware{}
+	}
+	w.State.MerchantStocks[id] = slices.Clone(stock)
+}
+
+// DoorID identifies the door between two locations, whatever the direction.
+func DoorID(from, to string) string {
+	if from > to {
+		from, to = to, from
+	}
+	return from + ":" + to
+}
+
+// OpenDoor marks the door between two locations as opened.
+func (w *World) OpenDoor(from, to string) {
+	door := DoorID(from, to)
+	if !slices.Contains(w.State.OpenedDoors, door) {
+		w.State.OpenedDoors = append(w.State.OpenedDoors, door)
+	}
+}
+
+// IsDoorOpen reports whether the door between two locations has been opened.
+func (w *World) IsDoorOpen(from, to string) bool {
+	return slices.Contains(w.State.OpenedDoors, DoorID(from, to))
+}
+
+// Visit records that the player entered a location.
+func (w *World) Visit(id string) {
+	if w.State.VisitedRooms == nil {
+		w.State.VisitedRooms = map[string]int{}
+	}
+	w.State.VisitedRooms[id]++
+}
+
+// HealedDuringVisit reports whether the healer of a location already healed the player
+// since the player entered it.
+func (w *World) HealedDuringVisit(id string) bool {
+	visit, healed := w.State.HealedOnVisit[id]
+	return healed && visit == w.State.VisitedRooms[id]
+}
+
+// Heal records that the healer of a location healed the player during the current visit.
+func (w *World) Heal(id string) {
+	if w.State.HealedOnVisit == nil {
+		w.State.HealedOnVisit = map[string]int{}
+	}
+	w.State.HealedOnVisit[id] = w.State.VisitedRooms[id]
+}
+
+// AbilityUsedDuringVisit reports whether the player already used its ability in a location
+// since entering it.
+func (w *World) AbilityUsedDuringVisit(id string) bool {
+	visit, used := w.State.AbilityUsedOnVisit[id]
+	return used && visit == w.State.VisitedRooms[id]
+}
+
+// UseAbility records that the player used its ability in a location during the current visit.
+func (w *World) UseAbility(id string) {
+	if w.State.AbilityUsedOnVisit == nil {
+		w.State.AbilityUsedOnVisit = map[string]int{}
+	}
+	w.State.AbilityUsedOnVisit[id] = w.State.VisitedRooms[id]
+}
+
+// StealTreasure marks the treasure of the monster of a location as stolen.
+func (w *World) StealTreasure(id string) {
+	if !slices.Contains(w.State.StolenTreasures, id) {
+		w.State.StolenTreasures = append(w.State.StolenTreasures, id)
+	}
+}
+
+// MeetNPC records that the player talked with the NPC of a location,
+// and reports whether it was the first time.
+func (w *World) MeetNPC(id string) bool {
+	if slices.Contains(w.State.MetNPCs, id) {
+		return false
+	}
+	w.State.MetNPCs = append(w.State.MetNPCs, id)
+	return true
+}
+
+// CheckID identifies a check of a location.
+func CheckID(location, check string) string {
+	return location + ":" + check
+}
+
+// PassCheck records that a check of a location succeeded: it cannot be attempted again.
+func (w *World) PassCheck(id, check string) {
+	if checkID := CheckID(id, check); !slices.Contains(w.State.PassedChecks, checkID) {
+		w.State.PassedChecks = append(w.State.PassedChecks, checkID)
+	}
+}
+
+// CheckFailedDuringVisit reports whether a check of a location already failed since
+// the player entered it.
+func (w *World) CheckFailedDuringVisit(id, check string) bool {
+	visit, failed := w.State.CheckAttemptedOnVisit[CheckID(id, check)]
+	return failed && visit == w.State.VisitedRooms[id]
+}
+
+// FailCheck records that a check of a location failed during the current visit.
+func (w *World) FailCheck(id, check string) {
+	if w.State.CheckAttemptedOnVisit == nil {
+		w.State.CheckAttemptedOnVisit = map[string]int{}
+	}
+	w.State.CheckAttemptedOnVisit[CheckID(id, check)] = w.State.VisitedRooms[id]
+}
+
+// MaxEvents is the number of events kept in the event log of a game.
+const MaxEvents = 100
+
+// LogEvent appends an event to the event log, dropping the oldest events beyond MaxEvents.
+func (w *World) LogEvent(kind, location, message string) {
+	w.State.Events = append(w.State.Events, models.Event{Kind: kind, Location: location, Message: message})
+	if excess := len(w.State.Events) - MaxEvents; excess > 0 {
+		w.State.Events = slices.Delete(w.State.Events, 0, excess)
+	}
+}
+
+// RecentEvents returns up to count of the latest events of the log, oldest first.
+func (w *World) RecentEvents(count int) []models.Event {
+	start := max(0, len(w.State.Events)-count)
+	return slices.Clone(w.State.Events[start:])
+}
+
+// CloneWorldState returns a copy of the state that shares nothing with the original.
+func CloneWorldState(state *models.WorldState) *models.WorldState {
+	clone := &models.WorldState{
+		MonsterHitPoints:      maps.Clone(state.MonsterHitPoints),
+		DefeatedMonsters:      slices.Clone(state.DefeatedMonsters),
+		TakenTreasures:        slices.Clone(state.TakenTreasures),
+		OpenedDoors:           slices.Clone(state.OpenedDoors),
+		VisitedRooms:          maps.Clone(state.VisitedRooms),
+		HealedOnVisit:         maps.Clone(state.HealedOnVisit),
+		MetNPCs:               slices.Clone(state.MetNPCs),
+		AbilityUsedOnVisit:    maps.Clone(state.AbilityUsedOnVisit),
+		StolenTreasures:       slices.Clone(state.StolenTreasures),
+		PassedChecks:          slices.Clone(state.PassedChecks),
+		CheckAttemptedOnVisit: maps.Clone(state.CheckAttemptedOnVisit),
+		Fights:                slices.Clone(state.Fights),
+		Events:                slices.Clone(state.Events),
+	}
+	if state.MerchantStocks != nil {
+		clone.MerchantStocks = make(map[string][]models.Ware, len(state.MerchantStocks))
+		for id, stock := range state.MerchantStocks {
+			clone.MerchantStocks[id] = slices.Clone(stock)
+		}
+	}
+	if state.ConsumedItems != nil {
+		clone.ConsumedItems = make(map[string]map[string]int, len(state.ConsumedItems))
+		for id, items := range state.ConsumedItems {
+			clone.ConsumedItems[id] = maps.Clone(items)
+		}
+	}
+	return clone
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"mcp-dungeon/game"
+	"mcp-dungeon/storage"
+	"mcp-dungeon/validator"
+)
+
+var (
+	generatorOptions    game.GeneratorOptions
+	generatorOutputFile string
+)
+
+func runGenerateDungeon(cmd *cobra.Command, args []string) error {
+	dungeon, err := game.GenerateDungeon(generatorOptions)
+	if err != nil {
+		return fmt.Errorf("failed to generate dungeon: %v", err)
+	}
+
+	// The generator must only produce valid dungeons
+	if issues := validator.ValidateDungeon(dungeon, nil); len(issues) > 0 {
+		for _, issue := range issues {
+			log.Printf("🔴 %s", issue)
+		}
+		return fmt.Errorf("the generated dungeon has %d issue(s)", len(issues))
+	}
+
+	if err := storage.SaveDungeonToYAML(dungeon, generatorOutputFile); err != nil {
+		return fmt.Errorf("failed to save dungeon: %v", err)
+	}
+	log.Printf("Generated dungeon %s (seed %d): %dx%d, %d locations", generatorOutputFile,
+		generatorOptions.Seed, dungeon.Size.Width, dungeon.Size.Height, len(dungeon.Locations))
+	return nil
+}
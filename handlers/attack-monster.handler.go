@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func AttackMonsterHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("🟢 AttackMonsterHandler called")
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	attack, err := engine.Attack()
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	player, monster, round := attack.Player, attack.Monster, attack.Round
+
+	result := fmt.Sprintf("⚔️ %s hits %s for %d damage (%d HP left)\n",
+		player.Name, monster.Name, round.PlayerDamage, monster.HitPoints)
+
+	if round.MonsterDefeated {
+		result += fmt.Sprintf("🏆 %s has been defeated! %s gains %d experience\n",
+			monster.Name, player.Name, attack.Experience)
+		result += levelUpMessages(player.Name, attack.LevelUps)
+		if attack.Treasure.Type != "" {
+			result += fmt.Sprintf("💰 %s collects the monster's treasure: %s worth %d\n",
+				player.Name, attack.Treasure.Type, attack.Treasure.Value)
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += fmt.Sprintf("🩸 %s hits %s for %d damage (%d/%d HP left)\n",
+		monster.Name, player.Name, round.MonsterDamage, player.HitPoints, player.MaxHitPoints)
+
+	if round.PlayerDefeated {
+		result += fmt.Sprintf("💀 %s has been defeated by %s\n", player.Name, monster.Name)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
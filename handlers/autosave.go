@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const autosaveSlotPrefix = "autosave_"
+
+// AutosaveSlot is the save slot used for the automatic saves of a session.
+func AutosaveSlot(sessionID string) string {
+	return autosaveSlotPrefix + strings.TrimPrefix(sessionID, sessionIDPrefix)
+}
+
+// Autosave saves the game of the session in its autosave slot,
+// unless nothing changed since the previous autosave.
+func (s *GameSession) Autosave(saveDir string) error {
+	s.autosaveMu.Lock()
+	defer s.autosaveMu.Unlock()
+
+	changes := s.Engine.Changes()
+	if changes == s.autosavedChanges {
+		return nil
+	}
+
+	if _, err := s.Save(saveDir, AutosaveSlot(s.ID)); err != nil {
+		return err
+	}
+	s.autosavedChanges = changes
+	log.Printf("💾 Autosaved session %s", s.ID)
+	return nil
+}
+
+// AutosaveAll autosaves every active session.
+func (m *SessionManager) AutosaveAll(saveDir string) {
+	m.mu.RLock()
+	sessions := make([]*GameSession, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, session)
+	}
+	m.mu.RUnlock()
+
+	for _, session := range sessions {
+		if err := session.Autosave(saveDir); err != nil {
+			log.Printf("🔴 Autosave of session %s failed: %v", session.ID, err)
+		}
+	}
+}
+
+// RunAutosave autosaves every active session at each interval until ctx is done.
+func (m *SessionManager) RunAutosave(ctx context.Context, saveDir string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.AutosaveAll(saveDir)
+		}
+	}
+}
+
+// AutosaveMiddleware autosaves the game of the calling session after every tool
+// call that changed it.
+func AutosaveMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+
+		if session := sessionFromContext(ctx); session != nil {
+			if saveErr := session.Autosave(SaveDir); saveErr != nil {
+				log.Printf("🔴 Autosave of session %s failed: %v", session.ID, saveErr)
+			}
+		}
+
+		return result, err
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func BuyItemHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 BuyItemHandler called with arguments: %v", args)
+
+	itemTypeValue, exists := args["item_type"]
+	if !exists {
+		return mcp.NewToolResultText("Missing required parameter: item_type"), nil
+	}
+
+	itemType, ok := itemTypeValue.(string)
+	if !ok {
+		return mcp.NewToolResultText("Invalid parameter type: item_type must be a string"), nil
+	}
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	trade, err := engine.BuyItem(itemType, request.GetInt("quantity", 1))
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("🛍️ %s bought %d %s from %s and paid %d gold (%d gold left)",
+		trade.Player.Name, trade.Item.Quantity, itemType, trade.Merchant.Name, trade.Gold, trade.Player.Gold)), nil
+}
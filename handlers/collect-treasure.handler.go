@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func CollectTreasureHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("🟢 CollectTreasureHandler called")
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	treasure, player, err := engine.CollectTreasure()
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	result := fmt.Sprintf("💰 %s collected %s worth %d in %s", player.Name, treasure.Type, treasure.Value, player.CurrentLocation)
+	if treasure.Type == "gold" {
+		result += fmt.Sprintf(" (gold: %d)", player.Gold)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
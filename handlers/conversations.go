@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	"mcp-dungeon/game"
+	"mcp-dungeon/llm"
+	"mcp-dungeon/models"
+)
+
+// maxConversationMessages bounds the history of a conversation sent to the model.
+const maxConversationMessages = 20
+
+// Talk sends the message of the player to the NPC of a location and returns its answer.
+// Every NPC keeps its own conversation with the session. Without chat model, or when
+// the model fails, the NPC uses the canned answers of its dialogue.
+func (s *GameSession) Talk(ctx context.Context, location models.Location, npc models.NPC, message string) string {
+	s.conversationsMu.Lock()
+	defer s.conversationsMu.Unlock()
+
+	if s.conversations == nil {
+		s.conversations = map[string][]llm.Message{}
+	}
+	history := s.conversations[location.ID]
+
+	answer := ""
+	if ChatClient != nil {
+		messages := []llm.Message{{Role: "system", Content: llm.NPCPersonaPrompt(s.Engine.Dungeon(), npc, location)}}
+		messages = append(messages, history...)
+		messages = append(messages, llm.Message{Role: "user", Content: message})
+
+		var err error
+		answer, err = ChatClient.Chat(ctx, messages, 0.7)
+		if err != nil {
+			log.Printf("🔴 Chat model failed for %s, using canned answers: %v", npc.Name, err)
+			answer = ""
+		}
+	}
+	if answer == "" {
+		answer = game.CannedAnswer(npc, len(history)/2)
+	}
+
+	history = append(history,
+		llm.Message{Role: "user", Content: message},
+		llm.Message{Role: "assistant", Content: answer},
+	)
+	if len(history) > maxConversationMessages {
+		history = history[len(history)-maxConversationMessages:]
+	}
+	s.conversations[location.ID] = history
+
+	return answer
+}
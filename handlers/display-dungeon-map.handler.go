@@ -3,21 +3,17 @@ package handlers
 import (
 	"context"
 	"log"
-	"mcp-dungeon/game"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 func DisplayDungeonMapHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	log.Printf("🟢 DisplayDungeonMapHandler called")
-	if CrystalCavernsDungeon == nil {
-		return mcp.NewToolResultText("Dungeon data not loaded"), nil
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
 	}
 
-	if CurrentPlayer == nil {
-		return mcp.NewToolResultText("Player not initialized"), nil
-	}
-
-	mapString := game.GenerateDungeonMap(CrystalCavernsDungeon, CurrentPlayer)
+	mapString := engine.Map()
 	return mcp.NewToolResultText(mapString), nil
 }
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/game"
+	"mcp-dungeon/models"
+)
+
+func EquipItemHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 EquipItemHandler called with arguments: %v", args)
+
+	itemTypeValue, exists := args["item_type"]
+	if !exists {
+		return mcp.NewToolResultText("Missing required parameter: item_type"), nil
+	}
+
+	itemType, ok := itemTypeValue.(string)
+	if !ok {
+		return mcp.NewToolResultText("Invalid parameter type: item_type must be a string"), nil
+	}
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	result, err := engine.EquipItem(itemType)
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	message := fmt.Sprintf("🛡️ %s equipped %s as %s (%s)", result.Player.Name, itemName(result.Item), result.Item.Slot, itemBonuses(result.Item))
+	if result.Replaced != nil {
+		message += fmt.Sprintf("\n🎒 %s went back to the inventory", itemName(*result.Replaced))
+	}
+	message += fmt.Sprintf("\n⚔️ Attack %d, defense %d",
+		game.EffectiveAttackPower(&result.Player), game.EffectiveDefense(&result.Player))
+
+	return mcp.NewToolResultText(message), nil
+}
+
+// itemName returns the display name of an item, or its type when it has no name.
+func itemName(item models.Item) string {
+	if item.Name != "" {
+		return item.Name
+	}
+	return item.Type
+}
+
+// itemBonuses describes the bonuses of an equipment, e.g. "+5 attack, +2 defense".
+func itemBonuses(item models.Item) string {
+	return fmt.Sprintf("%+d attack, %+d defense", item.AttackBonus, item.DefenseBonus)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// sweepInterval is how often the idle sessions and the ended session ids are dropped.
+const sweepInterval = time.Minute
+
+// terminatedRetention is how long the id of an ended session is remembered, so that
+// its client is told the session is over rather than unknown.
+const terminatedRetention = time.Hour
+
+// ExpireIdle ends the sessions without any request for longer than idleTimeout,
+// after an autosave so that their games can still be loaded, and forgets the
+// sessions that ended more than terminatedRetention ago.
+// An idleTimeout of 0 keeps the idle sessions.
+func (m *SessionManager) ExpireIdle(saveDir string, idleTimeout time.Duration) {
+	now := time.Now()
+
+	m.mu.Lock()
+	var expired []*GameSession
+	if idleTimeout > 0 {
+		for id, session := range m.sessions {
+			if now.Sub(session.LastUsed()) > idleTimeout {
+				expired = append(expired, session)
+				delete(m.sessions, id)
+				m.terminated[id] = now
+			}
+		}
+	}
+	for id, ended := range m.terminated {
+		if now.Sub(ended) > terminatedRetention {
+			delete(m.terminated, id)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, session := range expired {
+		if err := session.Autosave(saveDir); err != nil {
+			log.Printf("🔴 Autosave of session %s failed: %v", session.ID, err)
+		}
+		log.Printf("🎮 Game session %s expired after %s without requests", session.ID, idleTimeout)
+	}
+}
+
+// RunExpiry expires the idle sessions at each sweep interval until ctx is done.
+func (m *SessionManager) RunExpiry(ctx context.Context, saveDir string, idleTimeout time.Duration) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.ExpireIdle(saveDir, idleTimeout)
+		}
+	}
+}
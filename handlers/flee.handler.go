@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func FleeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("🟢 FleeHandler called")
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	flee, err := engine.Flee()
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	player, monster, check := flee.Player, flee.Monster, flee.Check
+	result := fmt.Sprintf("🎲 %s tries to flee from %s (agility against DC %d): rolled %d%+d = %d\n",
+		player.Name, monster.Name, check.Difficulty, check.Roll, check.Modifier, check.Total)
+
+	if check.Success {
+		result += fmt.Sprintf("💨 %s slips away before %s can react\n", player.Name, monster.Name)
+	} else {
+		result += fmt.Sprintf("🩸 %s hits %s while fleeing for %d damage (%d/%d HP left)\n",
+			monster.Name, player.Name, flee.Round.MonsterDamage, player.HitPoints, player.MaxHitPoints)
+	}
+
+	if !flee.Escaped {
+		result += fmt.Sprintf("💀 %s has been defeated by %s\n", player.Name, monster.Name)
+		return mcp.NewToolResultText(result), nil
+	}
+
+	location := flee.Move.Location
+	result += fmt.Sprintf("🏃 Player %s fled to %s at coordinates [%d, %d]\n",
+		player.Name, location.ID, location.Coordinates[0], location.Coordinates[1])
+	result += fmt.Sprintf("👹 %s stays behind with %d HP left\n", monster.Name, monster.HitPoints)
+	if flee.Move.ReachedExit {
+		result += fmt.Sprintf("🏁 %s has reached the exit of %s\n", player.Name, engine.Dungeon().Name)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
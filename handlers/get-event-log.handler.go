@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/game"
+)
+
+func GetEventLogHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("🟢 GetEventLogHandler called")
+
+	count := request.GetInt("count", 10)
+	if count < 1 || count > game.MaxEvents {
+		return mcp.NewToolResultText(fmt.Sprintf("Invalid count %d: must be between 1 and %d", count, game.MaxEvents)), nil
+	}
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	events := engine.Events(count)
+	if len(events) == 0 {
+		return mcp.NewToolResultText("📜 Nothing happened yet"), nil
+	}
+
+	result := "📜 Latest events, oldest first:\n"
+	for _, event := range events {
+		result += fmt.Sprintf("- [%s] %s\n", event.Location, event.Message)
+	}
+	return mcp.NewToolResultText(result), nil
+}
@@ -7,15 +7,32 @@ import (
 	"log"
 
 	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/game"
+	"mcp-dungeon/models"
 )
 
+// playerStatus is the player with its stats once its equipment is taken into account.
+type playerStatus struct {
+	models.Player
+	EffectiveAttackPower int `json:"effective_attack_power"`
+	EffectiveDefense     int `json:"effective_defense"`
+}
+
 func GetPlayerStatusHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	log.Printf("🟢 GetPlayerStatusHandler called")
-	if CurrentPlayer == nil {
-		return mcp.NewToolResultText("Player not initialized"), nil
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
 	}
 
-	jsonData, err := json.MarshalIndent(CurrentPlayer, "", "  ")
+	player := engine.Player()
+	status := playerStatus{
+		Player:               player,
+		EffectiveAttackPower: game.EffectiveAttackPower(&player),
+		EffectiveDefense:     game.EffectiveDefense(&player),
+	}
+	jsonData, err := json.MarshalIndent(status, "", "  ")
 	if err != nil {
 		return mcp.NewToolResultText(fmt.Sprintf("Error serializing player data: %v", err)), nil
 	}
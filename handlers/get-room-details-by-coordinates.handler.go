@@ -37,19 +37,23 @@ func GetRoomDetailsByCoordinatesHandler(ctx context.Context, request mcp.CallToo
 	x := int(xFloat)
 	y := int(yFloat)
 
-	if CrystalCavernsDungeon == nil {
-		return mcp.NewToolResultText("Dungeon data not loaded"), nil
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
 	}
 
-	for _, location := range CrystalCavernsDungeon.Locations {
-		if location.Coordinates[0] == x && location.Coordinates[1] == y {
-			jsonData, err := json.MarshalIndent(location, "", "  ")
-			if err != nil {
-				return mcp.NewToolResultText(fmt.Sprintf("Error serializing room data: %v", err)), nil
-			}
-			return mcp.NewToolResultText(string(jsonData)), nil
-		}
+	location, exists := engine.LocationAt(x, y)
+	if !exists && engine.IsObstacle(x, y) {
+		return mcp.NewToolResultText(fmt.Sprintf("The cell at coordinates [%d, %d] is an obstacle and cannot be entered", x, y)), nil
+	}
+	if !exists {
+		return mcp.NewToolResultText(fmt.Sprintf("No room found at coordinates [%d, %d]", x, y)), nil
+	}
+
+	jsonData, err := json.MarshalIndent(location, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error serializing room data: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("No room found at coordinates [%d, %d]", x, y)), nil
+	return mcp.NewToolResultText(string(jsonData)), nil
 }
@@ -24,11 +24,12 @@ func GetRoomDetailsHandler(ctx context.Context, request mcp.CallToolRequest) (*m
 		return mcp.NewToolResultText("Invalid parameter type: room_name must be a string"), nil
 	}
 
-	if CrystalCavernsDungeon == nil {
-		return mcp.NewToolResultText("Dungeon data not loaded"), nil
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
 	}
 
-	location, exists := CrystalCavernsDungeon.Locations[roomName]
+	location, exists := engine.Location(roomName)
 	if !exists {
 		return mcp.NewToolResultText(fmt.Sprintf("Room '%s' not found", roomName)), nil
 	}
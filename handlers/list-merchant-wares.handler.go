@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func ListMerchantWaresHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("🟢 ListMerchantWaresHandler called")
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	merchant, err := engine.MerchantWares()
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	if len(merchant.Stock) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("🛒 %s has nothing to sell", merchant.Name)), nil
+	}
+
+	var wares strings.Builder
+	fmt.Fprintf(&wares, "🛒 Wares of %s (you have %d gold):\n", merchant.Name, engine.Player().Gold)
+	for _, ware := range merchant.Stock {
+		fmt.Fprintf(&wares, "- %s x%d: %d gold each", ware.Type, ware.Quantity, ware.Price)
+		if ware.HealingLevel > 0 {
+			fmt.Fprintf(&wares, " (heals %d HP)", ware.HealingLevel)
+		}
+		if ware.Slot != "" {
+			fmt.Fprintf(&wares, " (%s, %s)", ware.Slot, itemBonuses(ware.Item))
+		}
+		if ware.Quantity == 0 {
+			wares.WriteString(" - sold out")
+		}
+		wares.WriteString("\n")
+	}
+
+	return mcp.NewToolResultText(wares.String()), nil
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/storage"
+)
+
+func ListSavesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("🟢 ListSavesHandler called")
+
+	saves, err := storage.ListSaves(SaveDir)
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error listing the saves: %v", err)), nil
+	}
+
+	if len(saves) == 0 {
+		return mcp.NewToolResultText("No saved games"), nil
+	}
+
+	result := "Saved games:\n"
+	for _, save := range saves {
+		result += fmt.Sprintf("- %s: %s in %s (%s), level %d, %d/%d HP, saved at %s\n",
+			save.Slot, save.Player.Name, save.Player.CurrentLocation, save.DungeonName,
+			save.Player.Level, save.Player.HitPoints, save.Player.MaxHitPoints,
+			save.SavedAt.Format("2006-01-02 15:04:05"))
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
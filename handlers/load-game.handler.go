@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func LoadGameHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 LoadGameHandler called with arguments: %v", args)
+
+	slotValue, exists := args["slot"]
+	if !exists {
+		return mcp.NewToolResultText("Missing required parameter: slot"), nil
+	}
+
+	slot, ok := slotValue.(string)
+	if !ok {
+		return mcp.NewToolResultText("Invalid parameter type: slot must be a string"), nil
+	}
+
+	session := sessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	save, err := session.Load(SaveDir, slot)
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error loading the game: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("📂 Game loaded from slot '%s' saved at %s: %s is in %s with %d/%d HP",
+		save.Slot, save.SavedAt.Format("2006-01-02 15:04:05"), save.Player.Name, save.Player.CurrentLocation,
+		save.Player.HitPoints, save.Player.MaxHitPoints)), nil
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"slices"
 	"context"
 	"fmt"
 	"log"
@@ -24,50 +23,22 @@ func MoveToRoomHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 		return mcp.NewToolResultText("Invalid parameter type: target_room must be a string"), nil
 	}
 
-	if CrystalCavernsDungeon == nil {
-		return mcp.NewToolResultText("Dungeon data not loaded"), nil
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
 	}
 
-	if CurrentPlayer == nil {
-		return mcp.NewToolResultText("Player not initialized"), nil
+	move, err := engine.Move(targetRoom)
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
 	}
 
-	targetLocation, exists := CrystalCavernsDungeon.Locations[targetRoom]
-	if !exists {
-		return mcp.NewToolResultText(fmt.Sprintf("Room '%s' does not exist", targetRoom)), nil
-	}
-
-	if CurrentPlayer.CurrentLocation == targetRoom {
-		return mcp.NewToolResultText(fmt.Sprintf("Player is already in room '%s'", targetRoom)), nil
-	}
-
-	currentLocation, exists := CrystalCavernsDungeon.Locations[CurrentPlayer.CurrentLocation]
-	if !exists {
-		return mcp.NewToolResultText(fmt.Sprintf("Current player location '%s' is invalid", CurrentPlayer.CurrentLocation)), nil
-	}
-
-	connected := slices.Contains(currentLocation.Connections, targetRoom)
-
-	/*
-		for _, connection := range currentLocation.Connections {
-			if connection == targetRoom {
-				connected = true
-				break
-			}
-		}
-
-
-	*/
+	result := fmt.Sprintf("Player %s moved to %s at coordinates [%d, %d]",
+		move.Player.Name, targetRoom, move.Location.Coordinates[0], move.Location.Coordinates[1])
 
-	if !connected {
-		return mcp.NewToolResultText(fmt.Sprintf("Cannot move to '%s' - not connected to current room '%s'", targetRoom, CurrentPlayer.CurrentLocation)), nil
+	if move.ReachedExit {
+		result += fmt.Sprintf("\n🏁 %s has reached the exit of %s", move.Player.Name, engine.Dungeon().Name)
 	}
 
-	CurrentPlayer.CurrentLocation = targetRoom
-	CurrentPlayer.Coordinates = targetLocation.Coordinates
-
-	result := fmt.Sprintf("Player %s moved to %s at coordinates [%d, %d]",
-		CurrentPlayer.Name, targetRoom, targetLocation.Coordinates[0], targetLocation.Coordinates[1])
-
 	return mcp.NewToolResultText(result), nil
 }
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func PickUpItemHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 PickUpItemHandler called with arguments: %v", args)
+
+	itemTypeValue, exists := args["item_type"]
+	if !exists {
+		return mcp.NewToolResultText("Missing required parameter: item_type"), nil
+	}
+
+	itemType, ok := itemTypeValue.(string)
+	if !ok {
+		return mcp.NewToolResultText("Invalid parameter type: item_type must be a string"), nil
+	}
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	item, err := engine.PickUpItem(itemType, request.GetInt("quantity", 0))
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("🎒 %s picked up %d %s", engine.Player().Name, item.Quantity, itemType)), nil
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"fmt"
+
+	"mcp-dungeon/game"
+)
+
+// levelUpMessages reports the levels gained by the player, one line per level.
+func levelUpMessages(playerName string, levelUps []game.LevelUp) string {
+	result := ""
+	for _, levelUp := range levelUps {
+		result += fmt.Sprintf("🎉 %s reached level %d! (+%d max HP, +%d attack, +%d defense)\n",
+			playerName, levelUp.Level, levelUp.Growth.MaxHitPoints, levelUp.Growth.AttackPower, levelUp.Growth.Defense)
+	}
+	return result
+}
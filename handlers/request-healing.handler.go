@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func RequestHealingHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("🟢 RequestHealingHandler called")
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	result, err := engine.RequestHealing()
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	player := result.Player
+	message := fmt.Sprintf("✨ %s healed %s: +%d HP (%d/%d HP, %s)",
+		result.Healer.Name, player.Name, result.Healed, player.HitPoints, player.MaxHitPoints, player.Status)
+	if result.Fee > 0 {
+		message += fmt.Sprintf(" for %d gold (%d gold left)", result.Fee, player.Gold)
+	}
+
+	return mcp.NewToolResultText(message), nil
+}
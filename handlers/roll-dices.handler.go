@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"log"
-	"math/rand"
 	"strconv"
 
 	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/game/dice"
 )
 
 func RollDicesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -14,23 +16,18 @@ func RollDicesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.Ca
 	nbDices := request.GetInt("nb_dices", 1)
 	sides := request.GetInt("nb_sides", 6)
 
+	// Same limits as the dice expressions of the roll tool
+	if nbDices < 1 || nbDices > dice.MaxDice {
+		return mcp.NewToolResultText(fmt.Sprintf("%d dice is out of range [1, %d]", nbDices, dice.MaxDice)), nil
+	}
+	if sides < 2 || sides > dice.MaxSides {
+		return mcp.NewToolResultText(fmt.Sprintf("%d sides is out of range [2, %d]", sides, dice.MaxSides)), nil
+	}
+
 	log.Printf("🎲 Rolling %d dice(s) with %d sides each...\n", nbDices, sides)
 
 	roll := func(n, x int) int {
-		if n <= 0 || x <= 0 {
-			return 0
-		}
-
-		results := make([]int, n)
-		sum := 0
-
-		for i := range n {
-			roll := rand.Intn(x) + 1
-			results[i] = roll
-			sum += roll
-		}
-
-		return sum
+		return rollDice(ctx, dice.Expression{Groups: []dice.Group{{Count: n, Sides: x}}}).Total
 	}
 
 	// Simulate rolling dice
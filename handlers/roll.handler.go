@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/game/dice"
+)
+
+// rollDice rolls with the random generator of the game of the session, so that a
+// seeded game replays the same rolls. Without a game, the dice are rolled at random.
+func rollDice(ctx context.Context, expression dice.Expression) dice.Result {
+	if engine := engineFromContext(ctx); engine != nil {
+		return engine.Roll(expression)
+	}
+	return expression.Roll(dice.DefaultSource)
+}
+
+func RollHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 RollHandler called with arguments: %v", args)
+
+	expressionValue, exists := args["expression"]
+	if !exists {
+		return mcp.NewToolResultText("Missing required parameter: expression"), nil
+	}
+
+	expression, ok := expressionValue.(string)
+	if !ok {
+		return mcp.NewToolResultText("Invalid parameter type: expression must be a string"), nil
+	}
+
+	parsed, err := dice.Parse(expression)
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+	result := rollDice(ctx, parsed)
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Error serializing roll result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/server"
+
+	"mcp-dungeon/game"
+	"mcp-dungeon/llm"
+	"mcp-dungeon/models"
+	"mcp-dungeon/storage"
+)
+
+const sessionIDPrefix = "mcp-session-"
+
+// GameSession is the game played by one MCP client: its own hero in its own world.
+type GameSession struct {
+	ID     string
+	Engine *game.Engine
+
+	// lastUsed is the time of the last request of the session, in Unix nanoseconds
+	lastUsed atomic.Int64
+
+	autosaveMu       sync.Mutex
+	autosavedChanges uint64
+
+	// conversations holds the talk of the player with each NPC, keyed by location
+	conversationsMu sync.Mutex
+	conversations   map[string][]llm.Message
+}
+
+// Save writes the game of the session to a save slot.
+func (s *GameSession) Save(saveDir, slot string) (*models.SaveGame, error) {
+	dungeon := s.Engine.Dungeon()
+	fingerprint, err := storage.DungeonFingerprint(dungeon)
+	if err != nil {
+		return nil, err
+	}
+
+	player, state, random := s.Engine.Snapshot()
+	save := &models.SaveGame{
+		Slot:               slot,
+		SavedAt:            time.Now(),
+		DungeonName:        dungeon.Name,
+		DungeonFingerprint: fingerprint,
+		Player:             player,
+		World:              state,
+		Random:             &random,
+	}
+
+	if err := storage.SaveGameToYAML(saveDir, save); err != nil {
+		return nil, err
+	}
+	return save, nil
+}
+
+// Load replaces the game of the session with a save slot made in the same dungeon.
+func (s *GameSession) Load(saveDir, slot string) (*models.SaveGame, error) {
+	save, err := storage.LoadGameFromYAML(saveDir, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	dungeon := s.Engine.Dungeon()
+	fingerprint, err := storage.DungeonFingerprint(dungeon)
+	if err != nil {
+		return nil, err
+	}
+
+	if save.DungeonName != dungeon.Name {
+		return nil, fmt.Errorf("save slot '%s' was made in dungeon '%s', not in the loaded dungeon '%s'",
+			slot, save.DungeonName, dungeon.Name)
+	}
+	if save.DungeonFingerprint != fingerprint {
+		return nil, fmt.Errorf("save slot '%s' was made in another version of dungeon '%s'", slot, dungeon.Name)
+	}
+
+	if err := game.ApplyClassSkills(&save.Player); err != nil {
+		return nil, fmt.Errorf("save slot '%s' has an invalid player: %v", slot, err)
+	}
+
+	if err := s.Engine.Restore(save.Player, save.World, save.Random); err != nil {
+		return nil, fmt.Errorf("save slot '%s' has an %v", slot, err)
+	}
+	return save, nil
+}
+
+// touch records a request of the session.
+func (s *GameSession) touch() {
+	s.lastUsed.Store(time.Now().UnixNano())
+}
+
+// LastUsed returns the time of the last request of the session.
+func (s *GameSession) LastUsed() time.Time {
+	return time.Unix(0, s.lastUsed.Load())
+}
+
+// SessionManager issues the Mcp-Session-Id of the StreamableHTTP server and
+// keeps one GameSession per id. A session is created on "initialize" and
+// dropped when the client terminates it, or when it stays idle too long
+// (see ExpireIdle).
+type SessionManager struct {
+	dungeon     *models.Dungeon
+	player      *models.Player
+	progression *models.Progression
+	// seed is the seed of every new game, nil gives each game a seed of its own
+	seed *uint64
+
+	mu       sync.RWMutex
+	sessions map[string]*GameSession
+	// terminated holds the end time of the sessions that ended recently
+	terminated map[string]time.Time
+}
+
+func NewSessionManager(dungeon *models.Dungeon, player *models.Player, progression *models.Progression, seed *uint64) *SessionManager {
+	return &SessionManager{
+		dungeon:     dungeon,
+		player:      player,
+		progression: progression,
+		seed:        seed,
+		sessions:    map[string]*GameSession{},
+		terminated:  map[string]time.Time{},
+	}
+}
+
+// Generate implements server.SessionIdManager: it starts a new game.
+func (m *SessionManager) Generate() string {
+	id := sessionIDPrefix + uuid.New().String()
+
+	seed := game.NewSeed()
+	if m.seed != nil {
+		seed = *m.seed
+	}
+
+	session := &GameSession{
+		ID:     id,
+		Engine: game.NewEngine(m.dungeon, m.player, m.progression, seed),
+	}
+	session.touch()
+
+	m.mu.Lock()
+	m.sessions[id] = session
+	m.mu.Unlock()
+
+	log.Printf("🎮 New game session %s for %s (seed %d)", id, m.player.Name, seed)
+	return id
+}
+
+// Validate implements server.SessionIdManager.
+func (m *SessionManager) Validate(sessionID string) (isTerminated bool, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ended := m.terminated[sessionID]; ended {
+		return true, nil
+	}
+	session, exists := m.sessions[sessionID]
+	if !exists {
+		return false, fmt.Errorf("unknown session id: %s", sessionID)
+	}
+	session.touch()
+	return false, nil
+}
+
+// Terminate implements server.SessionIdManager: the game of the session is discarded.
+func (m *SessionManager) Terminate(sessionID string) (isNotAllowed bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sessions[sessionID]; !exists {
+		return false, fmt.Errorf("unknown session id: %s", sessionID)
+	}
+	delete(m.sessions, sessionID)
+	m.terminated[sessionID] = time.Now()
+
+	log.Printf("🎮 Game session %s ended", sessionID)
+	return false, nil
+}
+
+// Get returns the game of a session, or nil if there is none.
+func (m *SessionManager) Get(sessionID string) *GameSession {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session := m.sessions[sessionID]
+	if session != nil {
+		session.touch()
+	}
+	return session
+}
+
+// sessionFromContext returns the game session of the MCP session making the request.
+func sessionFromContext(ctx context.Context) *GameSession {
+	if Sessions == nil {
+		return nil
+	}
+	clientSession := server.ClientSessionFromContext(ctx)
+	if clientSession == nil {
+		return nil
+	}
+	return Sessions.Get(clientSession.SessionID())
+}
+
+// engineFromContext returns the game engine of the MCP session making the request.
+func engineFromContext(ctx context.Context) *game.Engine {
+	session := sessionFromContext(ctx)
+	if session == nil {
+		return nil
+	}
+	return session.Engine
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/game"
+)
+
+func SkillCheckHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 SkillCheckHandler called with arguments: %v", args)
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	var check game.SkillCheckResult
+	var err error
+
+	if checkValue, exists := args["check"]; exists {
+		name, ok := checkValue.(string)
+		if !ok {
+			return mcp.NewToolResultText("Invalid parameter type: check must be a string"), nil
+		}
+		check, err = engine.AttemptCheck(name)
+	} else {
+		skillValue, exists := args["skill"]
+		if !exists {
+			return mcp.NewToolResultText("Missing required parameter: skill (or check)"), nil
+		}
+		skill, ok := skillValue.(string)
+		if !ok {
+			return mcp.NewToolResultText("Invalid parameter type: skill must be a string"), nil
+		}
+		if _, exists := args["difficulty"]; !exists {
+			return mcp.NewToolResultText("Missing required parameter: difficulty"), nil
+		}
+		difficulty, ok := args["difficulty"].(float64)
+		if !ok {
+			return mcp.NewToolResultText("Invalid parameter type: difficulty must be a number"), nil
+		}
+		check, err = engine.SkillCheck(skill, int(difficulty))
+	}
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	player := check.Player
+	var result string
+	if check.Check != nil {
+		result = fmt.Sprintf("🎲 %s tries to %s (%s against DC %d): rolled %d%+d = %d\n", player.Name,
+			game.CheckAction(*check.Check), check.Skill, check.Difficulty, check.Roll, check.Modifier, check.Total)
+	} else {
+		result = fmt.Sprintf("🎲 %s checks %s against DC %d: rolled %d%+d = %d\n",
+			player.Name, check.Skill, check.Difficulty, check.Roll, check.Modifier, check.Total)
+	}
+
+	switch check.Outcome {
+	case game.OutcomeCriticalSuccess:
+		result += "🌟 Critical success!\n"
+	case game.OutcomeSuccess:
+		result += "✅ Success\n"
+	case game.OutcomeFailure:
+		result += "❌ Failure\n"
+	case game.OutcomeCriticalFailure:
+		result += "💥 Critical failure!\n"
+	}
+
+	if check.Opened != "" {
+		result += fmt.Sprintf("🚪 The door to '%s' is open\n", check.Opened)
+	}
+	if check.Reward.Type != "" {
+		result += fmt.Sprintf("💰 %s finds %s worth %d\n", player.Name, check.Reward.Type, check.Reward.Value)
+	}
+	if check.Experience > 0 {
+		result += fmt.Sprintf("📖 %s gains %d experience\n", player.Name, check.Experience)
+		result += levelUpMessages(player.Name, check.LevelUps)
+	}
+	if check.Damage > 0 {
+		result += fmt.Sprintf("🩸 %s loses %d HP (%d/%d HP left)\n", player.Name, check.Damage, player.HitPoints, player.MaxHitPoints)
+		if game.IsDead(&player) {
+			result += fmt.Sprintf("💀 %s has died\n", player.Name)
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
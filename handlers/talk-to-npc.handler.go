@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TalkToNPCHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 TalkToNPCHandler called with arguments: %v", args)
+
+	messageValue, exists := args["message"]
+	if !exists {
+		return mcp.NewToolResultText("Missing required parameter: message"), nil
+	}
+
+	message, ok := messageValue.(string)
+	if !ok {
+		return mcp.NewToolResultText("Invalid parameter type: message must be a string"), nil
+	}
+
+	session := sessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	npc, location, err := session.Engine.CurrentNPC()
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	answer := session.Talk(ctx, location, npc, message)
+	result := fmt.Sprintf("🗣️ %s (%s): %s\n", npc.Name, npc.Type, answer)
+
+	// Meeting an NPC for the first time is worth some experience
+	if experience, levelUps := session.Engine.TalkedToNPC(location.ID); experience > 0 {
+		player := session.Engine.Player()
+		result += fmt.Sprintf("📖 %s gains %d experience for meeting %s\n", player.Name, experience, npc.Name)
+		result += levelUpMessages(player.Name, levelUps)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
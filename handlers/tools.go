@@ -1,10 +1,14 @@
 package handlers
 
-import (
-	"mcp-dungeon/models"
-)
+import "mcp-dungeon/llm"
 
 var (
-	CrystalCavernsDungeon *models.Dungeon
-	CurrentPlayer         *models.Player
+	// Sessions holds the game of every connected MCP client.
+	Sessions *SessionManager
+
+	// SaveDir is the directory holding the save slots.
+	SaveDir = "saves"
+
+	// ChatClient is the chat model playing the NPCs, nil to use their canned answers.
+	ChatClient *llm.Client
 )
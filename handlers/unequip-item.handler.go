@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/game"
+)
+
+func UnequipItemHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 UnequipItemHandler called with arguments: %v", args)
+
+	slotValue, exists := args["slot"]
+	if !exists {
+		return mcp.NewToolResultText("Missing required parameter: slot"), nil
+	}
+
+	slot, ok := slotValue.(string)
+	if !ok {
+		return mcp.NewToolResultText("Invalid parameter type: slot must be a string"), nil
+	}
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	item, player, err := engine.UnequipItem(slot)
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("🎒 %s put %s back in the inventory\n⚔️ Attack %d, defense %d",
+		player.Name, itemName(item), game.EffectiveAttackPower(&player), game.EffectiveDefense(&player))), nil
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-dungeon/game"
+)
+
+func UseAbilityHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("🟢 UseAbilityHandler called")
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	ability, err := engine.UseAbility()
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	player, monster, round := ability.Player, ability.Monster, ability.Round
+
+	var result string
+	switch ability.Ability {
+	case game.AbilityPowerStrike:
+		result = fmt.Sprintf("💥 %s lands a power strike on %s for %d damage (%d HP left)\n",
+			player.Name, monster.Name, round.PlayerDamage, monster.HitPoints)
+	case game.AbilitySpell:
+		result = fmt.Sprintf("🔮 %s casts a spell on %s for %d damage (%d HP left)\n",
+			player.Name, monster.Name, round.PlayerDamage, monster.HitPoints)
+	case game.AbilitySteal:
+		if ability.Stolen {
+			return mcp.NewToolResultText(fmt.Sprintf("🥷 %s sneaks up on %s and steals its treasure: %s worth %d\n",
+				player.Name, monster.Name, ability.Treasure.Type, ability.Treasure.Value)), nil
+		}
+		result = fmt.Sprintf("👀 %s tries to steal from %s but gets caught!\n", player.Name, monster.Name)
+	}
+
+	if round.MonsterDefeated {
+		result += fmt.Sprintf("🏆 %s has been defeated! %s gains %d experience\n",
+			monster.Name, player.Name, ability.Experience)
+		result += levelUpMessages(player.Name, ability.LevelUps)
+		if ability.Treasure.Type != "" {
+			result += fmt.Sprintf("💰 %s collects the monster's treasure: %s worth %d\n",
+				player.Name, ability.Treasure.Type, ability.Treasure.Value)
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	if round.MonsterDamage > 0 || round.PlayerDefeated {
+		result += fmt.Sprintf("🩸 %s hits %s for %d damage (%d/%d HP left)\n",
+			monster.Name, player.Name, round.MonsterDamage, player.HitPoints, player.MaxHitPoints)
+	}
+
+	if round.PlayerDefeated {
+		result += fmt.Sprintf("💀 %s has been defeated by %s\n", player.Name, monster.Name)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
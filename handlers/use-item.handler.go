@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func UseItemHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	log.Printf("🟢 UseItemHandler called with arguments: %v", args)
+
+	itemTypeValue, exists := args["item_type"]
+	if !exists {
+		return mcp.NewToolResultText("Missing required parameter: item_type"), nil
+	}
+
+	itemType, ok := itemTypeValue.(string)
+	if !ok {
+		return mcp.NewToolResultText("Invalid parameter type: item_type must be a string"), nil
+	}
+
+	engine := engineFromContext(ctx)
+	if engine == nil {
+		return mcp.NewToolResultText("Game session not found, initialize a new MCP session"), nil
+	}
+
+	healed, player, err := engine.UseInventoryItem(itemType)
+	if err != nil {
+		return mcp.NewToolResultText(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("🧪 %s used a %s and restored %d HP (%d/%d HP, %s)",
+		player.Name, itemType, healed, player.HitPoints, player.MaxHitPoints, player.Status)), nil
+}
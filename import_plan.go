@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"mcp-dungeon/plan"
+	"mcp-dungeon/storage"
+	"mcp-dungeon/validator"
+)
+
+var (
+	planOutputFile  string
+	planDungeonName string
+)
+
+func runImportPlan(cmd *cobra.Command, args []string) error {
+	planFile := "specs/dungeon.plan.md"
+	if len(args) > 0 {
+		planFile = args[0]
+	}
+
+	data, err := os.ReadFile(planFile)
+	if err != nil {
+		return fmt.Errorf("failed to read plan: %v", err)
+	}
+
+	grid, err := plan.Parse(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse plan %s: %v", planFile, err)
+	}
+
+	dungeon, err := grid.Dungeon(planDungeonName)
+	if err != nil {
+		return fmt.Errorf("failed to import plan %s: %v", planFile, err)
+	}
+
+	if err := storage.SaveDungeonToYAML(dungeon, planOutputFile); err != nil {
+		return fmt.Errorf("failed to save dungeon: %v", err)
+	}
+	log.Printf("Imported %s into %s: %dx%d, %d locations", planFile, planOutputFile,
+		dungeon.Size.Width, dungeon.Size.Height, len(dungeon.Locations))
+
+	// The skeleton is written anyway, so that the plan issues can be fixed in the YAML file
+	for _, issue := range validator.ValidateDungeon(dungeon, nil) {
+		log.Printf("🟠 %s", issue)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Package llm talks to an OpenAI-compatible chat completion endpoint,
+// like the Docker Model Runner used by bot.sh.
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+)
+
+// Defaults of the local Docker Model Runner, overridden by the same
+// environment variables as bot.sh.
+const (
+	DefaultBaseURL   = "http://localhost:12434/engines/llama.cpp/v1"
+	DefaultChatModel = "hf.co/menlo/lucy-128k-gguf:q4_k_m"
+)
+
+// BaseURLFromEnv returns MODEL_RUNNER_BASE_URL, or the local model runner URL.
+func BaseURLFromEnv() string {
+	if baseURL := os.Getenv("MODEL_RUNNER_BASE_URL"); baseURL != "" {
+		return baseURL
+	}
+	return DefaultBaseURL
+}
+
+// ChatModelFromEnv returns MODEL_RUNNER_CHAT_MODEL, or the default chat model.
+func ChatModelFromEnv() string {
+	if model := os.Getenv("MODEL_RUNNER_CHAT_MODEL"); model != "" {
+		return model
+	}
+	return DefaultChatModel
+}
+
+// Message is one message of a conversation with the model.
+type Message struct {
+	Role    string // "system", "user" or "assistant"
+	Content string
+}
+
+// Client sends conversations to a chat model.
+type Client struct {
+	client openai.Client
+	model  string
+}
+
+// NewClient creates a client for an OpenAI-compatible endpoint. The API key is read
+// from OPENAI_API_KEY, local model runners do not need one.
+func NewClient(baseURL, model string) *Client {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		apiKey = "no-api-key"
+	}
+	return &Client{
+		client: openai.NewClient(option.WithBaseURL(baseURL), option.WithAPIKey(apiKey)),
+		model:  model,
+	}
+}
+
+// Chat sends the conversation and returns the answer of the model.
+func (c *Client) Chat(ctx context.Context, messages []Message, temperature float64) (string, error) {
+	params := openai.ChatCompletionNewParams{
+		Model:       c.model,
+		Temperature: openai.Float(temperature),
+	}
+	for _, message := range messages {
+		switch message.Role {
+		case "system":
+			params.Messages = append(params.Messages, openai.SystemMessage(message.Content))
+		case "assistant":
+			params.Messages = append(params.Messages, openai.AssistantMessage(message.Content))
+		default:
+			params.Messages = append(params.Messages, openai.UserMessage(message.Content))
+		}
+	}
+
+	completion, err := c.client.Chat.Completions.New(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("the model %s returned no answer", c.model)
+	}
+	return strings.TrimSpace(completion.Choices[0].Message.Content), nil
+}
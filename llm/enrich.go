@@ -0,0 +1,119 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mcp-dungeon/models"
+)
+
+const enrichSystemPrompt = `You are the dungeon master of a fantasy dungeon crawler.
+You write short, evocative texts for the locations of a dungeon.
+Answer with a single JSON object and nothing else.`
+
+// locationTexts is the answer expected from the model for one location.
+type locationTexts struct {
+	Description        string `json:"description"`
+	MonsterName        string `json:"monster_name"`
+	MonsterDescription string `json:"monster_description"`
+	NPCName            string `json:"npc_name"`
+	NPCDescription     string `json:"npc_description"`
+}
+
+// EnrichLocation asks the model for the description of a location and the names of its
+// monster and NPC. Only these texts change: the id, type, coordinates, connections and
+// game values of the location are kept as they are.
+func EnrichLocation(ctx context.Context, client *Client, dungeon *models.Dungeon, id string) (models.Location, error) {
+	location, exists := dungeon.Locations[id]
+	if !exists {
+		return location, fmt.Errorf("location '%s' does not exist", id)
+	}
+
+	answer, err := client.Chat(ctx, []Message{
+		{Role: "system", Content: enrichSystemPrompt},
+		{Role: "user", Content: enrichPrompt(dungeon, location)},
+	}, 0.8)
+	if err != nil {
+		return location, err
+	}
+
+	var texts locationTexts
+	if err := json.Unmarshal([]byte(jsonObject(answer)), &texts); err != nil {
+		return location, fmt.Errorf("unexpected answer for '%s': %v", id, err)
+	}
+
+	if texts.Description != "" {
+		location.Description = texts.Description
+	}
+	if location.Monster != nil {
+		monster := *location.Monster
+		if texts.MonsterName != "" {
+			monster.Name = texts.MonsterName
+		}
+		if texts.MonsterDescription != "" {
+			monster.Description = texts.MonsterDescription
+		}
+		location.Monster = &monster
+	}
+	if location.NPC != nil {
+		npc := *location.NPC
+		if texts.NPCName != "" {
+			npc.Name = texts.NPCName
+		}
+		if texts.NPCDescription != "" {
+			npc.Description = texts.NPCDescription
+		}
+		location.NPC = &npc
+	}
+	return location, nil
+}
+
+func enrichPrompt(dungeon *models.Dungeon, location models.Location) string {
+	var prompt strings.Builder
+
+	fmt.Fprintf(&prompt, "Dungeon: %s\n", dungeon.Name)
+	if dungeon.Description != "" {
+		fmt.Fprintf(&prompt, "Theme: %s\n", dungeon.Description)
+	}
+	fmt.Fprintf(&prompt, "Location: %s, a %s", location.ID, location.Type)
+	switch location.ID {
+	case dungeon.EntranceRoom:
+		prompt.WriteString(" (the entrance of the dungeon)")
+	case dungeon.ExitRoom:
+		prompt.WriteString(" (the exit of the dungeon)")
+	}
+	fmt.Fprintf(&prompt, "\nIt leads to: %s\n", strings.Join(location.Connections, ", "))
+	if location.Treasure != nil {
+		fmt.Fprintf(&prompt, "It holds a treasure: %s\n", location.Treasure.Type)
+	}
+	for _, item := range location.Items {
+		fmt.Fprintf(&prompt, "It holds an item: %s\n", item.Type)
+	}
+
+	prompt.WriteString("\nWrite a JSON object with:\n")
+	prompt.WriteString(`- "description": one or two sentences describing the location` + "\n")
+	if monster := location.Monster; monster != nil {
+		fmt.Fprintf(&prompt, "- \"monster_name\": the name of the %s living here (difficulty %d/10)\n",
+			monster.Type, monster.DifficultyLevel)
+		prompt.WriteString(`- "monster_description": one sentence describing it` + "\n")
+	}
+	if npc := location.NPC; npc != nil {
+		fmt.Fprintf(&prompt, "- \"npc_name\": the name of the %s living here\n", npc.Type)
+		prompt.WriteString(`- "npc_description": one sentence describing them` + "\n")
+	}
+
+	return prompt.String()
+}
+
+// jsonObject extracts the JSON object of an answer, which models often wrap
+// in a markdown code block or in explanations.
+func jsonObject(answer string) string {
+	start := strings.Index(answer, "{")
+	end := strings.LastIndex(answer, "}")
+	if start < 0 || end < start {
+		return answer
+	}
+	return answer[start : end+1]
+}
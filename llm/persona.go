@@ -0,0 +1,40 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-dungeon/models"
+)
+
+// NPCPersonaPrompt is the system prompt that makes the model play an NPC in its room.
+func NPCPersonaPrompt(dungeon *models.Dungeon, npc models.NPC, location models.Location) string {
+	var prompt strings.Builder
+
+	fmt.Fprintf(&prompt, "You are %s, a %s in the dungeon \"%s\".\n", npc.Name, npc.Type, dungeon.Name)
+	if npc.Description != "" {
+		fmt.Fprintf(&prompt, "About you: %s\n", npc.Description)
+	}
+	if dungeon.Description != "" {
+		fmt.Fprintf(&prompt, "About the dungeon: %s\n", dungeon.Description)
+	}
+	fmt.Fprintf(&prompt, "You are in %s: %s\n", location.ID, location.Description)
+	fmt.Fprintf(&prompt, "From here, one can go to: %s\n", strings.Join(location.Connections, ", "))
+	if location.ID == dungeon.EntranceRoom {
+		prompt.WriteString("This is the entrance of the dungeon.\n")
+	}
+	if location.ID != dungeon.ExitRoom {
+		fmt.Fprintf(&prompt, "The way out of the dungeon is %s.\n", dungeon.ExitRoom)
+	}
+	if len(npc.Dialogue) > 0 {
+		prompt.WriteString("Things you like to say:\n")
+		for _, line := range npc.Dialogue {
+			fmt.Fprintf(&prompt, "- %s\n", line)
+		}
+	}
+
+	prompt.WriteString("\nYou are talking with an adventurer. Stay in character, answer in two or three sentences, ")
+	prompt.WriteString("and never mention that you are a model or a game character.")
+
+	return prompt.String()
+}
@@ -6,13 +6,18 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/charmbracelet/fang"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/cobra"
 
+	"mcp-dungeon/game"
 	"mcp-dungeon/handlers"
+	"mcp-dungeon/llm"
 	"mcp-dungeon/models"
 	myserver "mcp-dungeon/server"
 	"mcp-dungeon/storage"
@@ -23,8 +28,24 @@ var (
 	playerFile  string
 	port        string
 	generate    bool
+	playerClass string
+	saveDir     string
+
+	autosaveInterval time.Duration
+	autosaveOnChange bool
+	sessionTimeout   time.Duration
+
+	llmBaseURL string
+	chatModel  string
+
+	progressionFile string
+
+	seed uint64
 )
 
+// shutdownTimeout is how long in-flight MCP requests have to finish on shutdown.
+const shutdownTimeout = 10 * time.Second
+
 func runServer(cmd *cobra.Command, args []string) error {
 	// Generate sample player file if requested
 	if generate {
@@ -32,7 +53,11 @@ func runServer(cmd *cobra.Command, args []string) error {
 		if outputFile == "" {
 			outputFile = "player_sample.yaml"
 		}
-		err := storage.GeneratePlayerSample(outputFile)
+		samplePlayer, err := game.NewPlayer("Hero", "", playerClass)
+		if err != nil {
+			return fmt.Errorf("failed to generate player sample: %v", err)
+		}
+		err = storage.GeneratePlayerSample(samplePlayer, outputFile)
 		if err != nil {
 			return fmt.Errorf("failed to generate player sample: %v", err)
 		}
@@ -40,45 +65,87 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Load player from file or create default
+	// Load player from file or create default.
+	// This player is the template copied into every new game session.
+	var player *models.Player
 	if playerFile != "" {
 		var err error
-		handlers.CurrentPlayer, err = storage.LoadPlayerFromYAML(playerFile)
+		player, err = storage.LoadPlayerFromYAML(playerFile)
 		if err != nil {
 			return fmt.Errorf("failed to load player: %v", err)
 		}
-		log.Printf("Loaded player: %s", handlers.CurrentPlayer.Name)
-	} else {
-		handlers.CurrentPlayer = &models.Player{
-			Name:            "Bob",
-			Avatar:          "😝",
-			Type:            "adventurer",
-			CurrentLocation: "entrance_cave",
+		if err := game.ApplyClassSkills(player); err != nil {
+			return fmt.Errorf("failed to load player: %v", err)
 		}
+		log.Printf("Loaded player: %s", player.Name)
+	} else {
+		player, _ = game.NewPlayer("Bob", "😝", "warrior")
+	}
+
+	// Refuse to serve a broken dungeon
+	if err := checkDungeonFile(dungeonFile); err != nil {
+		return err
 	}
 
 	// Load dungeon data from YAML file
-	var err error
-	handlers.CrystalCavernsDungeon, err = storage.LoadDungeonFromYAML(dungeonFile)
+	dungeon, err := storage.LoadDungeonFromYAML(dungeonFile)
 	if err != nil {
 		return fmt.Errorf("failed to load dungeon: %v", err)
 	}
 
-	log.Printf("Loaded dungeon: %s", handlers.CrystalCavernsDungeon.Name)
-	log.Printf("Dungeon size: %dx%d", handlers.CrystalCavernsDungeon.Size.Width, handlers.CrystalCavernsDungeon.Size.Height)
-	log.Printf("Number of locations: %d", len(handlers.CrystalCavernsDungeon.Locations))
+	log.Printf("Loaded dungeon: %s", dungeon.Name)
+	log.Printf("Dungeon size: %dx%d", dungeon.Size.Width, dungeon.Size.Height)
+	log.Printf("Number of locations: %d", len(dungeon.Locations))
+
+	// Start at the dungeon entrance unless the player file names a location of this dungeon
+	if _, exists := dungeon.Locations[player.CurrentLocation]; !exists {
+		player.CurrentLocation = dungeon.EntranceRoom
+	}
 
 	// Initialize player coordinates
-	if entranceRoom, exists := handlers.CrystalCavernsDungeon.Locations[handlers.CurrentPlayer.CurrentLocation]; exists {
-		handlers.CurrentPlayer.Coordinates = entranceRoom.Coordinates
-		log.Printf("Player %s starting at %s [%d, %d]", handlers.CurrentPlayer.Name, handlers.CurrentPlayer.CurrentLocation,
-			handlers.CurrentPlayer.Coordinates[0], handlers.CurrentPlayer.Coordinates[1])
+	if entranceRoom, exists := dungeon.Locations[player.CurrentLocation]; exists {
+		player.Coordinates = entranceRoom.Coordinates
+		log.Printf("Player %s starting at %s [%d, %d]", player.Name, player.CurrentLocation,
+			player.Coordinates[0], player.Coordinates[1])
+	}
+
+	// Experience curve and stat growth, from the defaults or a progression file
+	progression := game.DefaultProgression()
+	if progressionFile != "" {
+		if err := storage.LoadProgressionFromYAML(progressionFile, &progression); err != nil {
+			return fmt.Errorf("failed to load progression: %v", err)
+		}
+		log.Printf("Loaded progression: %s", progressionFile)
+	}
+
+	// Every MCP session gets its own player and world. With --seed, every game
+	// starts from the same seed and replaying the same tool calls gives the same game.
+	var gameSeed *uint64
+	if cmd.Flags().Changed("seed") {
+		gameSeed = &seed
+		log.Printf("Every game starts with seed %d", seed)
+	}
+	handlers.Sessions = handlers.NewSessionManager(dungeon, player, &progression, gameSeed)
+	handlers.SaveDir = saveDir
+
+	// NPCs are played by a chat model when one is configured
+	if llmBaseURL != "" {
+		handlers.ChatClient = llm.NewClient(llmBaseURL, chatModel)
+		log.Printf("NPCs are played by %s (%s)", chatModel, llmBaseURL)
+	} else {
+		log.Println("No chat model configured, NPCs use their canned answers")
 	}
 
 	// Create MCP server
+	var serverOptions []server.ServerOption
+	if autosaveOnChange {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(handlers.AutosaveMiddleware))
+	}
+
 	s := server.NewMCPServer(
 		"mcp-dungeon",
 		"0.0.0",
+		serverOptions...,
 	)
 
 	// =================================================
@@ -107,6 +174,15 @@ func runServer(cmd *cobra.Command, args []string) error {
 
 	s.AddTool(rollDices, handlers.RollDicesHandler)
 
+	roll := mcp.NewTool("roll",
+		mcp.WithDescription(`Roll dice written in tabletop notation and return every die, the modifier and the total as JSON. Examples: "2d6+3", "4d6 drop lowest", "1d20 with advantage", "3d6 - 1d4", "6d6 keep highest 3".`),
+		mcp.WithString("expression",
+			mcp.Required(),
+			mcp.Description("The dice expression to roll (e.g. 2d6+3)."),
+		),
+	)
+	s.AddTool(roll, handlers.RollHandler)
+
 	getRoomDetails := mcp.NewTool("get_room_details_by_name",
 		mcp.WithDescription(`Get detailed information about a room by its name/ID.`),
 		mcp.WithString("room_name",
@@ -148,6 +224,155 @@ func runServer(cmd *cobra.Command, args []string) error {
 	)
 	s.AddTool(displayDungeonMap, handlers.DisplayDungeonMapHandler)
 
+	attackMonster := mcp.NewTool("attack_monster",
+		mcp.WithDescription(`Attack the monster in the current room. Resolves one combat round: the player strikes first, then the monster strikes back. The fight ends when the player or the monster reaches 0 HP.`),
+	)
+	s.AddTool(attackMonster, handlers.AttackMonsterHandler)
+
+	collectTreasure := mcp.NewTool("collect_treasure",
+		mcp.WithDescription(`Collect the treasure of the current room. Gold is added to the player's purse, gems and artifacts go to the inventory. Not possible while a living monster is in the room.`),
+	)
+	s.AddTool(collectTreasure, handlers.CollectTreasureHandler)
+
+	pickUpItem := mcp.NewTool("pick_up_item",
+		mcp.WithDescription(`Pick up an item (like a healing potion) from the current room and put it in the player's inventory.`),
+		mcp.WithString("item_type",
+			mcp.Required(),
+			mcp.Description("The type of the item to pick up (e.g. healing_potion)."),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Description("How many units to pick up. Defaults to the whole stack."),
+		),
+	)
+	s.AddTool(pickUpItem, handlers.PickUpItemHandler)
+
+	useItem := mcp.NewTool("use_item",
+		mcp.WithDescription(`Use an item from the player's inventory. A healing potion restores hit points up to the player's maximum.`),
+		mcp.WithString("item_type",
+			mcp.Required(),
+			mcp.Description("The type of the item to use (e.g. healing_potion)."),
+		),
+	)
+	s.AddTool(useItem, handlers.UseItemHandler)
+
+	saveGame := mcp.NewTool("save_game",
+		mcp.WithDescription(`Save the current game (player and dungeon state) in a named save slot.`),
+		mcp.WithString("slot",
+			mcp.Required(),
+			mcp.Description("The name of the save slot (letters, digits, '-' and '_')."),
+		),
+	)
+	s.AddTool(saveGame, handlers.SaveGameHandler)
+
+	loadGame := mcp.NewTool("load_game",
+		mcp.WithDescription(`Load a game from a named save slot. The save must have been made in the currently loaded dungeon.`),
+		mcp.WithString("slot",
+			mcp.Required(),
+			mcp.Description("The name of the save slot to load."),
+		),
+	)
+	s.AddTool(loadGame, handlers.LoadGameHandler)
+
+	listSaves := mcp.NewTool("list_saves",
+		mcp.WithDescription(`List the saved games, most recent first.`),
+	)
+	s.AddTool(listSaves, handlers.ListSavesHandler)
+
+	talkToNPC := mcp.NewTool("talk_to_npc",
+		mcp.WithDescription(`Talk to the NPC (merchant, healer, sage) of the current room. The NPC remembers the conversation.`),
+		mcp.WithString("message",
+			mcp.Required(),
+			mcp.Description("What the player says to the NPC."),
+		),
+	)
+	s.AddTool(talkToNPC, handlers.TalkToNPCHandler)
+
+	listMerchantWares := mcp.NewTool("list_merchant_wares",
+		mcp.WithDescription(`List the wares sold by the merchant of the current room, with their prices in gold.`),
+	)
+	s.AddTool(listMerchantWares, handlers.ListMerchantWaresHandler)
+
+	buyItem := mcp.NewTool("buy_item",
+		mcp.WithDescription(`Buy an item from the merchant of the current room. The price is paid with the player's gold and the item goes to the inventory.`),
+		mcp.WithString("item_type",
+			mcp.Required(),
+			mcp.Description("The type of the item to buy (e.g. healing_potion)."),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Description("How many units to buy. Defaults to 1."),
+		),
+	)
+	s.AddTool(buyItem, handlers.BuyItemHandler)
+
+	sellItem := mcp.NewTool("sell_item",
+		mcp.WithDescription(`Sell an item of the inventory to the merchant of the current room. Gems and artifacts are bought at their value, other items at half the merchant's price.`),
+		mcp.WithString("item_type",
+			mcp.Required(),
+			mcp.Description("The type of the item to sell (e.g. gem)."),
+		),
+		mcp.WithNumber("quantity",
+			mcp.Description("How many units to sell. Defaults to 1."),
+		),
+	)
+	s.AddTool(sellItem, handlers.SellItemHandler)
+
+	requestHealing := mcp.NewTool("request_healing",
+		mcp.WithDescription(`Ask the healer of the current room to restore the player to full health, for the healer's fee. A healer heals the player once per visit of its room.`),
+	)
+	s.AddTool(requestHealing, handlers.RequestHealingHandler)
+
+	useAbility := mcp.NewTool("use_ability",
+		mcp.WithDescription(`Use the signature ability of the player's class against the monster of the current room: power strike (warrior, double damage), spell (mage, the monster cannot strike back) or steal (thief, takes the monster's treasure without fighting). Usable once per visit of a room.`),
+	)
+	s.AddTool(useAbility, handlers.UseAbilityHandler)
+
+	equipItem := mcp.NewTool("equip_item",
+		mcp.WithDescription(`Equip a weapon or an armor from the player's inventory. Its attack and defense bonuses are added to the player's stats. The item previously in the same slot goes back to the inventory.`),
+		mcp.WithString("item_type",
+			mcp.Required(),
+			mcp.Description("The type of the item to equip (e.g. crystal_sword)."),
+		),
+	)
+	s.AddTool(equipItem, handlers.EquipItemHandler)
+
+	unequipItem := mcp.NewTool("unequip_item",
+		mcp.WithDescription(`Put the item of an equipment slot back in the player's inventory.`),
+		mcp.WithString("slot",
+			mcp.Required(),
+			mcp.Description("The equipment slot to empty: weapon or armor."),
+			mcp.Enum(models.ItemSlots...),
+		),
+	)
+	s.AddTool(unequipItem, handlers.UnequipItemHandler)
+
+	skillCheck := mcp.NewTool("skill_check",
+		mcp.WithDescription(`Roll a skill check: 1d20 plus the modifier of a skill of the player against a difficulty class. A natural 20 is a critical success and a natural 1 a critical failure. Either attempt a check of the current room by name (e.g. forcing a locked door, with its consequences), or give a skill and a difficulty for a free check. The outcome is recorded in the event log of the game.`),
+		mcp.WithString("check",
+			mcp.Description("The name of a check of the current room (see the checks of the room details)."),
+		),
+		mcp.WithString("skill",
+			mcp.Description("The skill of a free check."),
+			mcp.Enum(game.SkillNames...),
+		),
+		mcp.WithNumber("difficulty",
+			mcp.Description("The difficulty class of a free check, from 1 (trivial) to 30 (nearly impossible)."),
+		),
+	)
+	s.AddTool(skillCheck, handlers.SkillCheckHandler)
+
+	flee := mcp.NewTool("flee",
+		mcp.WithDescription(`Flee from the monster of the current room back to the room the player came from. The player rolls an agility check against 10 + half the monster difficulty; on a failure the monster gets a free hit before the player escapes. The monster keeps its remaining hit points.`),
+	)
+	s.AddTool(flee, handlers.FleeHandler)
+
+	getEventLog := mcp.NewTool("get_event_log",
+		mcp.WithDescription(`Get the latest events of the game, oldest first: the skill checks rolled by the player, with the room, the roll and the outcome. Use it to recall and narrate what happened so far.`),
+		mcp.WithNumber("count",
+			mcp.Description(fmt.Sprintf("The number of events to return, from 1 to %d (default 10).", game.MaxEvents)),
+		),
+	)
+	s.AddTool(getEventLog, handlers.GetEventLogHandler)
+
 	// Start the HTTP server
 	httpPort := port
 	if httpPort == "" {
@@ -165,13 +390,49 @@ func runServer(cmd *cobra.Command, args []string) error {
 	// Add MCP endpoint
 	httpServer := server.NewStreamableHTTPServer(s,
 		server.WithEndpointPath("/mcp"),
+		server.WithSessionIdManager(handlers.Sessions),
 	)
 
 	// Register MCP handler with the mux
 	mux.Handle("/mcp", httpServer)
 
 	// Start the HTTP server with custom mux
-	log.Fatal(http.ListenAndServe(":"+httpPort, mux))
+	httpSrv := &http.Server{
+		Addr:    ":" + httpPort,
+		Handler: mux,
+	}
+
+	// The command context is cancelled on SIGINT/SIGTERM (see fang.WithNotifySignal)
+	ctx := cmd.Context()
+
+	if autosaveInterval > 0 {
+		log.Printf("Autosaving every %s", autosaveInterval)
+		go handlers.Sessions.RunAutosave(ctx, saveDir, autosaveInterval)
+	}
+	go handlers.Sessions.RunExpiry(ctx, saveDir, sessionTimeout)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- httpSrv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("HTTP server failed: %v", err)
+	case <-ctx.Done():
+	}
+
+	// Stop accepting requests and let the in-flight ones finish
+	log.Println("Shutting down the MCP server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error while shutting down the HTTP server: %v", err)
+	}
+
+	// Nothing can change the games anymore: save them
+	handlers.Sessions.AutosaveAll(saveDir)
+	log.Println("MCP server stopped")
 	return nil
 }
 
@@ -183,12 +444,71 @@ func main() {
 		RunE:  runServer,
 	}
 
-	rootCmd.Flags().StringVar(&dungeonFile, "dungeon-file", "crystal_caverns.yaml", "Path to the dungeon YAML file")
+	rootCmd.PersistentFlags().StringVar(&dungeonFile, "dungeon-file", "crystal_caverns.yaml", "Path to the dungeon YAML file")
 	rootCmd.Flags().StringVar(&playerFile, "player-file", "", "Path to the player YAML file")
 	rootCmd.Flags().StringVar(&port, "port", "9090", "HTTP server port")
 	rootCmd.Flags().BoolVar(&generate, "generate-player", false, "Generate a sample player YAML file")
+	rootCmd.Flags().StringVar(&playerClass, "class", "warrior", "Class of the generated player ("+strings.Join(game.ClassNames(), ", ")+")")
+	rootCmd.Flags().StringVar(&saveDir, "save-dir", "saves", "Directory where the save slots are stored")
+	rootCmd.Flags().DurationVar(&autosaveInterval, "autosave-interval", 0, "Autosave every active session at this interval (e.g. 5m, 0 to disable)")
+	rootCmd.Flags().BoolVar(&autosaveOnChange, "autosave-on-change", false, "Autosave a session after every tool call that changed its game")
+	rootCmd.Flags().DurationVar(&sessionTimeout, "session-timeout", 30*time.Minute, "End the sessions without any request for this long, after an autosave (0 to keep them)")
+	rootCmd.Flags().Uint64Var(&seed, "seed", 0, "Seed of the random generator of every game (default: a random seed per game)")
+	rootCmd.Flags().StringVar(&progressionFile, "progression-file", "", "Path to a progression YAML file (experience curve and stat growth per class)")
+	rootCmd.Flags().StringVar(&llmBaseURL, "llm-base-url", os.Getenv("MODEL_RUNNER_BASE_URL"), "Base URL of the OpenAI-compatible API playing the NPCs (defaults to MODEL_RUNNER_BASE_URL, empty for canned answers)")
+	rootCmd.Flags().StringVar(&chatModel, "chat-model", llm.ChatModelFromEnv(), "Chat model playing the NPCs (defaults to MODEL_RUNNER_CHAT_MODEL)")
+
+	validateCmd := &cobra.Command{
+		Use:   "validate [dungeon files...]",
+		Short: "Validate dungeon YAML files",
+		Long:  "Check the connections, coordinates, entrance/exit and game rules of dungeon YAML files (defaults to --dungeon-file)",
+		RunE:  runValidate,
+	}
+	rootCmd.AddCommand(validateCmd)
+
+	importPlanCmd := &cobra.Command{
+		Use:   "import-plan [plan file]",
+		Short: "Create a dungeon YAML skeleton from an ASCII plan",
+		Long:  "Read an ASCII dungeon plan (S/E/R/C/./# grid, defaults to specs/dungeon.plan.md) and write a dungeon YAML skeleton with ids, coordinates and connections",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runImportPlan,
+	}
+	importPlanCmd.Flags().StringVar(&planOutputFile, "output", "dungeon_skeleton.yaml", "Path of the dungeon YAML file to write")
+	importPlanCmd.Flags().StringVar(&planDungeonName, "name", "Imported Dungeon", "Name of the dungeon")
+	rootCmd.AddCommand(importPlanCmd)
+
+	generateDungeonCmd := &cobra.Command{
+		Use:   "generate-dungeon",
+		Short: "Generate a random dungeon YAML file",
+		Long:  "Build a random dungeon whose exit can always be reached from the entrance. The same flags and seed always give the same dungeon",
+		Args:  cobra.NoArgs,
+		RunE:  runGenerateDungeon,
+	}
+	generateDungeonCmd.Flags().StringVar(&generatorOutputFile, "output", "generated_dungeon.yaml", "Path of the dungeon YAML file to write")
+	generateDungeonCmd.Flags().StringVar(&generatorOptions.Name, "name", "Generated Dungeon", "Name of the dungeon")
+	generateDungeonCmd.Flags().IntVar(&generatorOptions.Width, "width", 6, "Width of the dungeon grid")
+	generateDungeonCmd.Flags().IntVar(&generatorOptions.Height, "height", 6, "Height of the dungeon grid")
+	generateDungeonCmd.Flags().Float64Var(&generatorOptions.Density, "density", 0.4, "Share of the grid cells that are rooms or corridors (0 to 1)")
+	generateDungeonCmd.Flags().Float64Var(&generatorOptions.CorridorRatio, "corridor-ratio", 0.4, "Share of the locations that are corridors (0 to 1)")
+	generateDungeonCmd.Flags().IntVar(&generatorOptions.Monsters, "monsters", 3, "Number of monsters")
+	generateDungeonCmd.Flags().IntVar(&generatorOptions.NPCs, "npcs", 2, "Number of NPCs")
+	generateDungeonCmd.Flags().IntVar(&generatorOptions.Treasures, "treasures", 3, "Number of treasures")
+	generateDungeonCmd.Flags().Uint64Var(&generatorOptions.Seed, "seed", 1, "Seed of the random generator")
+	rootCmd.AddCommand(generateDungeonCmd)
+
+	enrichDungeonCmd := &cobra.Command{
+		Use:   "enrich-dungeon <dungeon file>",
+		Short: "Write the descriptions and names of a dungeon with an LLM",
+		Long:  "Ask a model of an OpenAI-compatible endpoint (like Docker Model Runner) to write the location descriptions and the monster and NPC names of a dungeon. Ids, coordinates and connections are kept unchanged",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runEnrichDungeon,
+	}
+	enrichDungeonCmd.Flags().StringVar(&enrichOutputFile, "output", "enriched_dungeon.yaml", "Path of the dungeon YAML file to write")
+	enrichDungeonCmd.Flags().StringVar(&enrichBaseURL, "llm-base-url", llm.BaseURLFromEnv(), "Base URL of the OpenAI-compatible API (defaults to MODEL_RUNNER_BASE_URL)")
+	enrichDungeonCmd.Flags().StringVar(&enrichChatModel, "chat-model", llm.ChatModelFromEnv(), "Chat model (defaults to MODEL_RUNNER_CHAT_MODEL)")
+	rootCmd.AddCommand(enrichDungeonCmd)
 
-	if err := fang.Execute(context.Background(), rootCmd); err != nil {
+	if err := fang.Execute(context.Background(), rootCmd, fang.WithNotifySignal(os.Interrupt, syscall.SIGTERM)); err != nil {
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,26 @@
+package models
+
+// MaxExperienceGrowth is the highest experience growth a progression file may set.
+const MaxExperienceGrowth = 10.0
+
+// Progression configures how players earn experience and grow with levels.
+type Progression struct {
+	// ExperienceBase is the experience needed to go from level 1 to level 2
+	ExperienceBase int `yaml:"experience_base"`
+	// ExperienceGrowth multiplies the experience needed by each next level
+	ExperienceGrowth float64 `yaml:"experience_growth"`
+	MaxLevel         int     `yaml:"max_level"`
+	// MonsterExperience is the experience earned per difficulty level of a defeated monster
+	MonsterExperience int `yaml:"monster_experience"`
+	// NPCExperience is the experience earned for the first conversation with each NPC
+	NPCExperience int `yaml:"npc_experience"`
+	// Growth overrides the stat increase per level of the classes
+	Growth map[string]StatGrowth `yaml:"growth,omitempty"`
+}
+
+// StatGrowth is the increase of the player stats at each level up.
+type StatGrowth struct {
+	MaxHitPoints int `json:"max_hit_points" yaml:"max_hit_points"`
+	AttackPower  int `json:"attack_power" yaml:"attack_power"`
+	Defense      int `json:"defense" yaml:"defense"`
+}
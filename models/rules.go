@@ -0,0 +1,38 @@
+package models
+
+// LocationTypeObstacle is the type of the locations that cannot be entered.
+const LocationTypeObstacle = "obstacle"
+
+// Equipment slots of the player.
+const (
+	ItemSlotWeapon = "weapon"
+	ItemSlotArmor  = "armor"
+)
+
+// Values allowed by the dungeon specification (specs/specs.en.md).
+var (
+	LocationTypes = []string{"room", "corridor", LocationTypeObstacle}
+	MonsterTypes  = []string{"goblin", "orc", "dragon"}
+	NPCTypes      = []string{"merchant", "healer", "sage"}
+	TreasureTypes = []string{"gold", "gem", "artifact"}
+	ItemSlots     = []string{ItemSlotWeapon, ItemSlotArmor}
+)
+
+const (
+	MinDoors            = 1
+	MaxDoors            = 4
+	MinDifficultyLevel  = 1
+	MaxDifficultyLevel  = 10
+	MinMonsterHitPoints = 1
+	MaxMonsterHitPoints = 100
+	MinTreasureValue    = 1
+	MaxTreasureValue    = 1000
+	MinHealingLevel     = 1
+	MaxHealingLevel     = 100
+	MinSkill            = 1
+	MaxSkill            = 20
+	MinItemBonus        = 0
+	MaxItemBonus        = 20
+	MinDifficultyClass  = 1
+	MaxDifficultyClass  = 30
+)
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Size struct {
 	Width  int `yaml:"width"`
 	Height int `yaml:"height"`
@@ -9,11 +11,30 @@ type NPC struct {
 	Type        string `yaml:"type"`
 	Name        string `yaml:"name"`
 	Description string `yaml:"description"`
+	// Dialogue holds the canned answers used when no chat model is configured
+	Dialogue []string `yaml:"dialogue,omitempty"`
+	// Stock holds the wares of a merchant
+	Stock []Ware `yaml:"stock,omitempty"`
+	// HealingFee is the gold a healer asks to heal the player
+	HealingFee int `yaml:"healing_fee,omitempty"`
+}
+
+// Ware is an item sold by a merchant, at a price in gold per unit.
+type Ware struct {
+	Item  `yaml:",inline"`
+	Price int `yaml:"price"`
 }
 
+// Item is a stack of identical items. An item with a slot ("weapon" or "armor") can be
+// equipped, and its bonuses are added to the stats of the player while it is equipped.
 type Item struct {
 	Type         string `yaml:"type"`
+	Name         string `yaml:"name,omitempty"`
+	Slot         string `yaml:"slot,omitempty"`
 	HealingLevel int    `yaml:"healing_level,omitempty"`
+	AttackBonus  int    `yaml:"attack_bonus,omitempty"`
+	DefenseBonus int    `yaml:"defense_bonus,omitempty"`
+	Value        int    `yaml:"value,omitempty"`
 	Quantity     int    `yaml:"quantity"`
 }
 
@@ -31,16 +52,37 @@ type Monster struct {
 	Treasure        Treasure `yaml:"treasure"`
 }
 
+// Check is a skill check the player can attempt in a location, like forcing a door.
+// A check succeeds when 1d20 + the modifier of the skill reaches the difficulty class.
+type Check struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Skill       string `yaml:"skill"`
+	Difficulty  int    `yaml:"difficulty"`
+	// Opens is a locked door of the location opened by a success
+	Opens string `yaml:"opens,omitempty"`
+	// Reward and Experience are given for a success
+	Reward     *Treasure `yaml:"reward,omitempty"`
+	Experience int       `yaml:"experience,omitempty"`
+	// Damage is the hit points lost on a failure
+	Damage int `yaml:"damage,omitempty"`
+}
+
 type Location struct {
-	ID          string    `yaml:"id"`
-	Type        string    `yaml:"type"`
-	Coordinates [2]int    `yaml:"coordinates"`
-	Description string    `yaml:"description"`
-	Connections []string  `yaml:"connections"`
+	ID          string   `yaml:"id"`
+	Type        string   `yaml:"type"`
+	Coordinates [2]int   `yaml:"coordinates"`
+	Description string   `yaml:"description"`
+	Connections []string `yaml:"connections"`
+	OneWay      []string `yaml:"one_way,omitempty"`
+	// LockedDoors are connections that cannot be used until a check opens them, from either side
+	LockedDoors []string  `yaml:"locked_doors,omitempty"`
+	Checks      []Check   `yaml:"checks,omitempty"`
 	NPC         *NPC      `yaml:"npc,omitempty"`
 	Items       []Item    `yaml:"items,omitempty"`
 	Treasure    *Treasure `yaml:"treasure,omitempty"`
 	Monster     *Monster  `yaml:"monster,omitempty"`
+	Guard       string    `yaml:"guard,omitempty"`
 }
 
 type Dungeon struct {
@@ -50,21 +92,92 @@ type Dungeon struct {
 	EntranceRoom string              `yaml:"entrance_room"`
 	ExitRoom     string              `yaml:"exit_room"`
 	Locations    map[string]Location `yaml:"locations"`
+	// Obstacles are cells that cannot be entered. Locations of type "obstacle" are obstacles too.
+	Obstacles [][2]int `yaml:"obstacles,omitempty"`
 }
 
 type Player struct {
-	Name            string `json:"name" yaml:"name"`
-	Avatar          string `json:"avatar" yaml:"avatar"`
-	Type            string `json:"type" yaml:"type"`
-	Level           int    `json:"level" yaml:"level"`
-	HitPoints       int    `json:"hit_points" yaml:"hit_points"`
-	MaxHitPoints    int    `json:"max_hit_points" yaml:"max_hit_points"`
-	AttackPower     int    `json:"attack_power" yaml:"attack_power"`
-	Defense         int    `json:"defense" yaml:"defense"`
-	Experience      int    `json:"experience" yaml:"experience"`
-	Gold            int    `json:"gold" yaml:"gold"`
-	CurrentLocation string `json:"current_location" yaml:"current_location"`
-	Coordinates     [2]int `json:"coordinates" yaml:"coordinates"`
-	Inventory       []Item `json:"inventory" yaml:"inventory"`
-	Status          string `json:"status" yaml:"status"`
-}
\ No newline at end of file
+	Name             string `json:"name" yaml:"name"`
+	Avatar           string `json:"avatar" yaml:"avatar"`
+	Type             string `json:"type" yaml:"type"`
+	Level            int    `json:"level" yaml:"level"`
+	HitPoints        int    `json:"hit_points" yaml:"hit_points"`
+	MaxHitPoints     int    `json:"max_hit_points" yaml:"max_hit_points"`
+	AttackPower      int    `json:"attack_power" yaml:"attack_power"`
+	Defense          int    `json:"defense" yaml:"defense"`
+	Skills           `yaml:",inline"`
+	Experience       int    `json:"experience" yaml:"experience"`
+	Gold             int    `json:"gold" yaml:"gold"`
+	CurrentLocation  string `json:"current_location" yaml:"current_location"`
+	PreviousLocation string `json:"previous_location,omitempty" yaml:"previous_location,omitempty"`
+	Coordinates      [2]int `json:"coordinates" yaml:"coordinates"`
+	Inventory        []Item `json:"inventory" yaml:"inventory"`
+	Weapon           *Item  `json:"weapon,omitempty" yaml:"weapon,omitempty"`
+	Armor            *Item  `json:"armor,omitempty" yaml:"armor,omitempty"`
+	Status           string `json:"status" yaml:"status"`
+}
+
+// Skills are the abilities of a player, scored like in tabletop games: 10 is average.
+// Strength drives combat, agility fleeing and avoiding traps, intelligence NPC
+// interactions and spellcasting.
+type Skills struct {
+	Strength     int `json:"strength" yaml:"strength"`
+	Agility      int `json:"agility" yaml:"agility"`
+	Intelligence int `json:"intelligence" yaml:"intelligence"`
+}
+
+// WorldState holds what changed in a dungeon since the game started.
+// It overlays the static Dungeon template and is serialized on its own.
+type WorldState struct {
+	MonsterHitPoints map[string]int            `json:"monster_hit_points,omitempty" yaml:"monster_hit_points,omitempty"`
+	DefeatedMonsters []string                  `json:"defeated_monsters,omitempty" yaml:"defeated_monsters,omitempty"`
+	TakenTreasures   []string                  `json:"taken_treasures,omitempty" yaml:"taken_treasures,omitempty"`
+	ConsumedItems    map[string]map[string]int `json:"consumed_items,omitempty" yaml:"consumed_items,omitempty"`
+	OpenedDoors      []string                  `json:"opened_doors,omitempty" yaml:"opened_doors,omitempty"`
+	VisitedRooms     map[string]int            `json:"visited_rooms,omitempty" yaml:"visited_rooms,omitempty"`
+	// MerchantStocks replaces the stock of the merchants who traded with the player, keyed by location
+	MerchantStocks map[string][]Ware `json:"merchant_stocks,omitempty" yaml:"merchant_stocks,omitempty"`
+	// HealedOnVisit is the visit (see VisitedRooms) during which the healer of a location last healed the player
+	HealedOnVisit map[string]int `json:"healed_on_visit,omitempty" yaml:"healed_on_visit,omitempty"`
+	// MetNPCs lists the locations whose NPC already talked with the player
+	MetNPCs []string `json:"met_npcs,omitempty" yaml:"met_npcs,omitempty"`
+	// AbilityUsedOnVisit is the visit (see VisitedRooms) during which the player last used its ability in a location
+	AbilityUsedOnVisit map[string]int `json:"ability_used_on_visit,omitempty" yaml:"ability_used_on_visit,omitempty"`
+	// StolenTreasures lists the locations whose monster was robbed of its treasure
+	StolenTreasures []string `json:"stolen_treasures,omitempty" yaml:"stolen_treasures,omitempty"`
+	// PassedChecks lists the checks of the locations that succeeded, as "location:check"
+	PassedChecks []string `json:"passed_checks,omitempty" yaml:"passed_checks,omitempty"`
+	// CheckAttemptedOnVisit is the visit (see VisitedRooms) during which a check, as "location:check", last failed
+	CheckAttemptedOnVisit map[string]int `json:"check_attempted_on_visit,omitempty" yaml:"check_attempted_on_visit,omitempty"`
+	// Fights lists the locations whose monster is fighting the player: the player
+	// attacked it or got caught, and neither won nor fled yet
+	Fights []string `json:"fights,omitempty" yaml:"fights,omitempty"`
+	// Events is the log of what happened in the game, oldest first
+	Events []Event `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+// Event is an entry of the event log of a game.
+type Event struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Location string `json:"location" yaml:"location"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+// RandomState is the random generator of a game: its seed and, once the game has
+// started, where the generator is in its sequence.
+type RandomState struct {
+	Seed  uint64 `json:"seed" yaml:"seed"`
+	State string `json:"state,omitempty" yaml:"state,omitempty"`
+}
+
+// SaveGame is a named snapshot of a game, stored in the save directory.
+type SaveGame struct {
+	Slot               string     `json:"slot" yaml:"slot"`
+	SavedAt            time.Time  `json:"saved_at" yaml:"saved_at"`
+	DungeonName        string     `json:"dungeon_name" yaml:"dungeon_name"`
+	DungeonFingerprint string     `json:"dungeon_fingerprint" yaml:"dungeon_fingerprint"`
+	Player             Player     `json:"player" yaml:"player"`
+	World              WorldState `json:"world" yaml:"world"`
+	// Random is missing from the saves made before the games were seeded
+	Random *RandomState `json:"random,omitempty" yaml:"random,omitempty"`
+}
@@ -0,0 +1,199 @@
+// Package plan reads the ASCII dungeon plans of specs/dungeon.plan.md.
+package plan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mcp-dungeon/models"
+)
+
+// Cell symbols of a dungeon plan.
+const (
+	SymbolEntrance = "S"
+	SymbolExit     = "E"
+	SymbolRoom     = "R"
+	SymbolCorridor = "C"
+	SymbolEmpty    = "."
+	SymbolObstacle = "#"
+)
+
+// Plan is the grid of a dungeon plan, indexed by [y][x].
+type Plan [][]string
+
+// Parse reads the grid of a dungeon plan. When the text is a markdown document,
+// the grid is read from its first code block. The optional header row of column
+// numbers and the row numbers at the start of each line are ignored.
+func Parse(text string) (Plan, error) {
+	lines := gridLines(text)
+
+	var grid Plan
+	for _, line := range lines {
+		cells := strings.Fields(line.text)
+		if len(cells) == 0 || isHeader(cells) {
+			continue
+		}
+		if _, err := strconv.Atoi(cells[0]); err == nil {
+			cells = cells[1:]
+		}
+
+		for x, cell := range cells {
+			switch cell {
+			case SymbolEntrance, SymbolExit, SymbolRoom, SymbolCorridor, SymbolEmpty, SymbolObstacle:
+			default:
+				return nil, fmt.Errorf("line %d: unknown cell '%s' at column %d", line.number, cell, x)
+			}
+		}
+		grid = append(grid, cells)
+	}
+
+	if len(grid) == 0 {
+		return nil, fmt.Errorf("no dungeon grid found")
+	}
+	return grid, nil
+}
+
+type planLine struct {
+	number int
+	text   string
+}
+
+// gridLines returns the lines of the first code block, or every line if there is none.
+func gridLines(text string) []planLine {
+	var all, block []planLine
+	inBlock, blockFound := false, false
+
+	for i, text := range strings.Split(text, "\n") {
+		line := planLine{number: i + 1, text: text}
+		if strings.HasPrefix(strings.TrimSpace(text), "```") {
+			if inBlock {
+				blockFound = true
+				break
+			}
+			inBlock = true
+			continue
+		}
+		if inBlock {
+			block = append(block, line)
+		}
+		all = append(all, line)
+	}
+
+	if blockFound || inBlock {
+		return block
+	}
+	return all
+}
+
+// isHeader reports whether a row only holds column numbers.
+func isHeader(cells []string) bool {
+	for _, cell := range cells {
+		if _, err := strconv.Atoi(cell); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Width is the number of columns of the widest row.
+func (p Plan) Width() int {
+	width := 0
+	for _, row := range p {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+// Cell returns the symbol at the given coordinates, or SymbolEmpty outside the grid.
+func (p Plan) Cell(x, y int) string {
+	if y < 0 || y >= len(p) || x < 0 || x >= len(p[y]) {
+		return SymbolEmpty
+	}
+	return p[y][x]
+}
+
+func isLocation(symbol string) bool {
+	return symbol != SymbolEmpty && symbol != SymbolObstacle
+}
+
+// Dungeon builds a dungeon skeleton from the plan: every room and corridor gets an id
+// and its coordinates, orthogonally adjacent cells are connected, and obstacles are listed.
+// Descriptions, monsters, NPCs and treasures are left to be filled in.
+func (p Plan) Dungeon(name string) (*models.Dungeon, error) {
+	dungeon := &models.Dungeon{
+		Name:      name,
+		Size:      models.Size{Width: p.Width(), Height: len(p)},
+		Locations: map[string]models.Location{},
+	}
+
+	// Ids are given in reading order
+	ids := map[[2]int]string{}
+	rooms, corridors := 0, 0
+	for y, row := range p {
+		for x, symbol := range row {
+			var id string
+			switch symbol {
+			case SymbolEntrance:
+				if dungeon.EntranceRoom != "" {
+					return nil, fmt.Errorf("the plan has more than one entrance (%s)", SymbolEntrance)
+				}
+				id = "entrance"
+				dungeon.EntranceRoom = id
+			case SymbolExit:
+				if dungeon.ExitRoom != "" {
+					return nil, fmt.Errorf("the plan has more than one exit (%s)", SymbolExit)
+				}
+				id = "exit"
+				dungeon.ExitRoom = id
+			case SymbolRoom:
+				rooms++
+				id = fmt.Sprintf("room_%d", rooms)
+			case SymbolCorridor:
+				corridors++
+				id = fmt.Sprintf("corridor_%d", corridors)
+			case SymbolObstacle:
+				dungeon.Obstacles = append(dungeon.Obstacles, [2]int{x, y})
+			}
+			if id != "" {
+				ids[[2]int{x, y}] = id
+			}
+		}
+	}
+
+	if dungeon.EntranceRoom == "" {
+		return nil, fmt.Errorf("the plan has no entrance (%s)", SymbolEntrance)
+	}
+	if dungeon.ExitRoom == "" {
+		return nil, fmt.Errorf("the plan has no exit (%s)", SymbolExit)
+	}
+
+	// Neighbours in north, east, south, west order
+	directions := [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+	for coordinates, id := range ids {
+		x, y := coordinates[0], coordinates[1]
+
+		locationType := "room"
+		if p.Cell(x, y) == SymbolCorridor {
+			locationType = "corridor"
+		}
+
+		connections := []string{}
+		for _, direction := range directions {
+			nx, ny := x+direction[0], y+direction[1]
+			if isLocation(p.Cell(nx, ny)) {
+				connections = append(connections, ids[[2]int{nx, ny}])
+			}
+		}
+
+		dungeon.Locations[id] = models.Location{
+			ID:          id,
+			Type:        locationType,
+			Coordinates: coordinates,
+			Connections: connections,
+		}
+	}
+
+	return dungeon, nil
+}
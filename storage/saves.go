@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"mcp-dungeon/models"
+)
+
+const saveFileExtension = ".save.yaml"
+
+var validSlotName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// DungeonFingerprint identifies the content of a dungeon, so that a save
+// can only be loaded into the dungeon it was made in.
+func DungeonFingerprint(dungeon *models.Dungeon) (string, error) {
+	data, err := yaml.Marshal(dungeon)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func saveFilePath(saveDir, slot string) (string, error) {
+	if !validSlotName.MatchString(slot) {
+		return "", fmt.Errorf("invalid save slot name '%s': use letters, digits, '-' and '_' only", slot)
+	}
+	return filepath.Join(saveDir, slot+saveFileExtension), nil
+}
+
+func SaveGameToYAML(saveDir string, save *models.SaveGame) error {
+	filename, err := saveFilePath(saveDir, save.Slot)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(saveDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(save)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a crash never leaves a truncated save
+	tmpFile := filename + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, filename)
+}
+
+func LoadGameFromYAML(saveDir, slot string) (*models.SaveGame, error) {
+	filename, err := saveFilePath(saveDir, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("save slot '%s' does not exist", slot)
+		}
+		return nil, err
+	}
+
+	var save models.SaveGame
+	err = yaml.Unmarshal(data, &save)
+	if err != nil {
+		return nil, err
+	}
+	if save.Slot == "" {
+		return nil, fmt.Errorf("save slot '%s' is empty or corrupt", slot)
+	}
+
+	return &save, nil
+}
+
+// ListSaves returns the saves found in the save directory, most recent first.
+// A save that cannot be read is logged and left out, so that the others can still be used.
+func ListSaves(saveDir string) ([]*models.SaveGame, error) {
+	entries, err := os.ReadDir(saveDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var saves []*models.SaveGame
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), saveFileExtension) {
+			continue
+		}
+		save, err := LoadGameFromYAML(saveDir, strings.TrimSuffix(entry.Name(), saveFileExtension))
+		if err != nil {
+			log.Printf("🔴 Skipping unreadable save %s: %v", entry.Name(), err)
+			continue
+		}
+		saves = append(saves, save)
+	}
+
+	sort.Slice(saves, func(i, j int) bool {
+		return saves[i].SavedAt.After(saves[j].SavedAt)
+	})
+
+	return saves, nil
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 
 	"gopkg.in/yaml.v3"
@@ -23,6 +25,46 @@ func LoadDungeonFromYAML(filename string) (*models.Dungeon, error) {
 	return &dungeon, nil
 }
 
+// SaveDungeonToYAML writes a dungeon in the layout of the shipped dungeon files,
+// with short lists like coordinates and connections on a single line.
+func SaveDungeonToYAML(dungeon *models.Dungeon, filename string) error {
+	var document yaml.Node
+	if err := document.Encode(dungeon); err != nil {
+		return err
+	}
+	flowScalarSequences(&document)
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, buffer.Bytes(), 0644)
+}
+
+// flowScalarSequences switches the sequences that only hold scalars to the [a, b] style.
+func flowScalarSequences(node *yaml.Node) {
+	if node.Kind == yaml.SequenceNode {
+		scalars := true
+		for _, child := range node.Content {
+			if child.Kind != yaml.ScalarNode {
+				scalars = false
+			}
+		}
+		if scalars {
+			node.Style = yaml.FlowStyle
+		}
+	}
+	for _, child := range node.Content {
+		flowScalarSequences(child)
+	}
+}
+
 func LoadPlayerFromYAML(filename string) (*models.Player, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
@@ -47,23 +89,40 @@ func SavePlayerToYAML(player *models.Player, filename string) error {
 	return os.WriteFile(filename, data, 0644)
 }
 
-func GeneratePlayerSample(filename string) error {
-	samplePlayer := &models.Player{
-		Name:            "Hero",
-		Avatar:          "🗡️",
-		Type:            "warrior",
-		Level:           1,
-		HitPoints:       100,
-		MaxHitPoints:    100,
-		AttackPower:     15,
-		Defense:         10,
-		Experience:      0,
-		Gold:            50,
-		CurrentLocation: "entrance_cave",
-		Coordinates:     [2]int{0, 0},
-		Inventory:       []models.Item{{Type: "potion", HealingLevel: 25, Quantity: 2}},
-		Status:          "healthy",
+// LoadProgressionFromYAML reads a progression file over the given progression:
+// the settings missing from the file keep their current value. Settings that would break
+// the experience curve are rejected.
+func LoadProgressionFromYAML(filename string, progression *models.Progression) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(data, progression); err != nil {
+		return err
+	}
+
+	switch {
+	case progression.ExperienceBase < 1:
+		return fmt.Errorf("experience_base must be at least 1, got %d", progression.ExperienceBase)
+	case !(progression.ExperienceGrowth >= 1 && progression.ExperienceGrowth <= models.MaxExperienceGrowth):
+		return fmt.Errorf("experience_growth must be between 1 and %g, got %g", models.MaxExperienceGrowth, progression.ExperienceGrowth)
+	case progression.MaxLevel < 1:
+		return fmt.Errorf("max_level must be at least 1, got %d", progression.MaxLevel)
+	case progression.MonsterExperience < 0:
+		return fmt.Errorf("monster_experience cannot be negative, got %d", progression.MonsterExperience)
+	case progression.NPCExperience < 0:
+		return fmt.Errorf("npc_experience cannot be negative, got %d", progression.NPCExperience)
 	}
+	return nil
+}
+
+// GeneratePlayerSample writes a new player to a file, with some gold and potions to start with.
+// The player starts at the entrance of the dungeon.
+func GeneratePlayerSample(player *models.Player, filename string) error {
+	samplePlayer := *player
+	samplePlayer.Gold = 50
+	samplePlayer.Inventory = []models.Item{{Type: "potion", HealingLevel: 25, Quantity: 2}}
 
-	return SavePlayerToYAML(samplePlayer, filename)
-}
\ No newline at end of file
+	return SavePlayerToYAML(&samplePlayer, filename)
+}
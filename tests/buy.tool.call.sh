@@ -0,0 +1,31 @@
+#!/bin/bash
+: <<'COMMENT'
+# Use tool "add"
+COMMENT
+
+# STEP 1: Load the session ID from the environment file
+source mcp.env
+
+MCP_SERVER=${MCP_SERVER:-"http://localhost:9090"}
+
+read -r -d '' DATA <<- EOM
+{
+  "jsonrpc": "2.0",
+  "id": "test",
+  "method": "tools/call",
+  "params": {
+    "name": "buy_item",
+    "arguments": {
+      "item_type": "healing_potion",
+      "quantity": 1
+    }
+  }
+}
+EOM
+
+curl ${MCP_SERVER}/mcp \
+  -H "Content-Type: application/json" \
+  -H "Mcp-Session-Id: $SESSION_ID" \
+  -d "${DATA}" | jq 
+
+
@@ -0,0 +1,103 @@
+#!/bin/bash
+: <<'COMMENT'
+# Determinism test suite
+
+Starts the server with a fixed seed and plays the same tool calls in
+two sessions: every result must be the same. Then loads the autosave
+of a session, taken right after a dice roll, in a third session and
+checks that the next calls replay exactly as in the original session.
+
+Usage: ./determinism.sh [seed]
+COMMENT
+
+SEED=${1:-42}
+PORT=${DETERMINISM_PORT:-9192}
+MCP_SERVER="http://localhost:${PORT}"
+
+ROOT_DIR="$(cd "$(dirname "$0")/.." && pwd)"
+WORK_DIR=$(mktemp -d)
+trap 'kill ${SERVER_PID} 2>/dev/null; wait ${SERVER_PID} 2>/dev/null; rm -rf "${WORK_DIR}"' EXIT
+
+echo "🔨 Building the server..."
+(cd "${ROOT_DIR}" && go build -o "${WORK_DIR}/mcp-dungeon" .) || exit 1
+
+"${WORK_DIR}/mcp-dungeon" --dungeon-file "${ROOT_DIR}/crystal_caverns.yaml" --port "${PORT}" \
+  --save-dir "${WORK_DIR}/saves" --seed "${SEED}" --autosave-on-change > "${WORK_DIR}/server.log" 2>&1 &
+SERVER_PID=$!
+
+for i in $(seq 1 50); do
+  curl -s "${MCP_SERVER}/health" > /dev/null && break
+  sleep 0.2
+done
+
+function init_session() {
+  curl -i -s -X POST \
+    -H "Content-Type: application/json" \
+    -d '{"jsonrpc": "2.0", "method": "initialize", "id": "init", "params": {"protocolVersion": "2024-11-05"}}' \
+    "${MCP_SERVER}/mcp" | grep -i "mcp-session-id:" | cut -d' ' -f2 | tr -d '\r\n'
+}
+
+function call_tool() {
+  local session_id="$1"
+  local tool_name="$2"
+  local tool_arguments="$3"
+  curl -s -X POST \
+    -H "Content-Type: application/json" \
+    -H "Mcp-Session-Id: ${session_id}" \
+    -d "{\"jsonrpc\": \"2.0\", \"id\": \"call\", \"method\": \"tools/call\", \"params\": {\"name\": \"${tool_name}\", \"arguments\": ${tool_arguments}}}" \
+    "${MCP_SERVER}/mcp" | jq -r '.result.content[0].text'
+}
+
+# The way to the Crystalback Bruiser, with rolls and checks on the way
+function explore() {
+  local session_id="$1"
+  call_tool "${session_id}" roll '{"expression": "2d6+3"}'
+  call_tool "${session_id}" rool_dices '{"nb_dices": 3, "nb_sides": 8}'
+  call_tool "${session_id}" skill_check '{"skill": "agility", "difficulty": 12}'
+  for room in crystal_workshop corridor_2 merchants_den corridor_4 guardian_chamber; do
+    call_tool "${session_id}" move_to_room_by_name "{\"target_room\": \"${room}\"}"
+  done
+  call_tool "${session_id}" roll '{"expression": "1d20 with advantage"}'
+}
+
+# A fight with the Crystalback Bruiser
+function fight() {
+  local session_id="$1"
+  call_tool "${session_id}" attack_monster '{}'
+  call_tool "${session_id}" use_ability '{}'
+  call_tool "${session_id}" attack_monster '{}'
+  call_tool "${session_id}" flee '{}'
+  call_tool "${session_id}" roll '{"expression": "4d6 drop lowest"}'
+}
+
+FAILED=0
+
+function compare() {
+  local description="$1"
+  if diff "$2" "$3" > "${WORK_DIR}/diff"; then
+    echo "✅ ${description}"
+  else
+    echo "❌ ${description}:"
+    cat "${WORK_DIR}/diff"
+    FAILED=1
+  fi
+}
+
+FIRST=$(init_session)
+SECOND=$(init_session)
+
+echo "🎲 Playing the same calls in two sessions with seed ${SEED}..."
+explore "${FIRST}" > "${WORK_DIR}/first.explore"
+explore "${SECOND}" > "${WORK_DIR}/second.explore"
+compare "Same seed, same calls, same results" "${WORK_DIR}/first.explore" "${WORK_DIR}/second.explore"
+
+# The last call of explore is a roll: the autosave taken after it must hold
+# the generator as the roll left it
+echo "💾 Replaying the fight from the autosave of the first session..."
+THIRD=$(init_session)
+call_tool "${THIRD}" load_game "{\"slot\": \"autosave_${FIRST#mcp-session-}\"}" > /dev/null
+fight "${FIRST}" > "${WORK_DIR}/first.fight"
+fight "${THIRD}" > "${WORK_DIR}/third.fight"
+compare "A loaded autosave replays the same results" "${WORK_DIR}/first.fight" "${WORK_DIR}/third.fight"
+
+exit ${FAILED}
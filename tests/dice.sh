@@ -0,0 +1,108 @@
+#!/bin/bash
+: <<'COMMENT'
+# Dice notation test suite
+
+Starts the server and rolls a table of dice expressions with the roll
+tool. A valid expression must be read as the expected groups of dice,
+roll the expected number of dice and add up to the sum of the kept dice
+plus the modifier. An invalid expression must be rejected with the
+expected message.
+
+Usage: ./dice.sh
+COMMENT
+
+PORT=${DICE_PORT:-9193}
+MCP_SERVER="http://localhost:${PORT}"
+
+ROOT_DIR="$(cd "$(dirname "$0")/.." && pwd)"
+WORK_DIR=$(mktemp -d)
+trap 'kill ${SERVER_PID} 2>/dev/null; wait ${SERVER_PID} 2>/dev/null; rm -rf "${WORK_DIR}"' EXIT
+
+echo "🔨 Building the server..."
+(cd "${ROOT_DIR}" && go build -o "${WORK_DIR}/mcp-dungeon" .) || exit 1
+
+"${WORK_DIR}/mcp-dungeon" --dungeon-file "${ROOT_DIR}/crystal_caverns.yaml" --port "${PORT}" \
+  --save-dir "${WORK_DIR}/saves" > "${WORK_DIR}/server.log" 2>&1 &
+SERVER_PID=$!
+
+for i in $(seq 1 50); do
+  curl -s "${MCP_SERVER}/health" > /dev/null && break
+  sleep 0.2
+done
+
+SESSION_ID=$(curl -i -s -X POST \
+  -H "Content-Type: application/json" \
+  -d '{"jsonrpc": "2.0", "method": "initialize", "id": "init", "params": {"protocolVersion": "2024-11-05"}}' \
+  "${MCP_SERVER}/mcp" | grep -i "mcp-session-id:" | cut -d' ' -f2 | tr -d '\r\n')
+
+function roll() {
+  jq -n --arg expression "$1" \
+    '{jsonrpc: "2.0", id: "roll", method: "tools/call", params: {name: "roll", arguments: {expression: $expression}}}' |
+    curl -s -X POST \
+      -H "Content-Type: application/json" \
+      -H "Mcp-Session-Id: ${SESSION_ID}" \
+      -d @- "${MCP_SERVER}/mcp" | jq -r '.result.content[0].text'
+}
+
+FAILED=0
+
+# valid EXPRESSION NOTATION DICE DROPPED
+function valid() {
+  local summary
+  summary=$(roll "$1" | jq -r '
+    [.groups[].dice[]] as $dice |
+    (([.groups[] | .total] | add) + .modifier) as $sum |
+    "\(.expression)|\($dice | length)|\([$dice[] | select(.dropped)] | length)|\(.total == $sum)"' 2> /dev/null)
+  if [ "${summary}" = "$2|$3|$4|true" ]; then
+    echo "✅ '$1'"
+  else
+    echo "❌ '$1': expected '$2|$3|$4|true', got '${summary}'"
+    FAILED=1
+  fi
+}
+
+# invalid EXPRESSION MESSAGE
+function invalid() {
+  local message
+  message=$(roll "$1")
+  if [ "${message}" = "invalid dice expression '$1': $2" ]; then
+    echo "✅ '$1' is rejected"
+  else
+    echo "❌ '$1': expected '$2', got '${message}'"
+    FAILED=1
+  fi
+}
+
+echo "🎲 Valid expressions..."
+valid "2d6+3" "2d6 + 3" 2 0
+valid "d20" "1d20" 1 0
+valid "d%" "1d100" 1 0
+valid "3d8 - 1d4 + 2" "3d8 - 1d4 + 2" 4 0
+valid "-1d4+5" "-1d4 + 5" 1 0
+valid "4d6dl1" "4d6 drop lowest" 4 1
+valid "4d6 drop lowest" "4d6 drop lowest" 4 1
+valid "5d10 drop highest 2" "5d10 drop highest 2" 5 2
+valid "4d6kh3" "4d6 drop lowest" 4 1
+valid "2d20 keep lowest" "2d20 drop highest" 2 1
+valid "1d6 kh1" "1d6" 1 0
+valid "1d20 adv" "1d20 with advantage" 2 1
+valid "1d20 with disadvantage" "1d20 with disadvantage" 2 1
+valid "2D6 + 1D8" "2d6 + 1d8" 3 0
+
+echo "🎲 Invalid expressions..."
+invalid "5" "there are no dice to roll"
+invalid "2d" "expected a number, got the end of the expression"
+invalid "0d6" "0 dice is out of range [1, 100]"
+invalid "101d6" "101 dice is out of range [1, 100]"
+invalid "1d1" "1 sides is out of range [2, 1000]"
+invalid "1d1001" "1001 sides is out of range [2, 1000]"
+invalid "1d6*2" "unexpected '*'"
+invalid "4d6 drop" "expected lowest or highest after 'drop', got the end of the expression"
+invalid "1d6 with luck" "expected advantage or disadvantage after 'with', got 'luck'"
+invalid "2d6dl2" "cannot drop 2 of 2 dice"
+invalid "2d6kh3" "cannot keep 3 of 2 dice"
+invalid "4d6dl1 adv" "'adv': a group of dice takes a single drop, keep or advantage rule"
+invalid "1d6 kh1 dl1" "'dl': a group of dice takes a single drop, keep or advantage rule"
+invalid "1d4+1d4+1d4+1d4+1d4+1d4+1d4+1d4+1d4+1d4+1d4" "more than 10 groups of dice"
+
+exit ${FAILED}
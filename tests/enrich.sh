@@ -0,0 +1,59 @@
+#!/bin/bash
+: <<'COMMENT'
+# Enrich dungeon test
+
+Starts the LLM stub (tests/llm-stub), imports the ASCII plan into a
+skeleton, enriches it with the stub, and checks that the ids,
+coordinates and connections are unchanged while the descriptions
+were written. Then enriches a generated dungeon to check the monster
+and NPC names.
+
+Usage: ./enrich.sh
+COMMENT
+
+PORT=${STUB_PORT:-12500}
+
+ROOT_DIR="$(cd "$(dirname "$0")/.." && pwd)"
+WORK_DIR=$(mktemp -d)
+trap 'kill ${STUB_PID} 2>/dev/null; rm -rf "${WORK_DIR}"' EXIT
+
+echo "🔨 Building the server and the LLM stub..."
+(cd "${ROOT_DIR}" && go build -o "${WORK_DIR}/mcp-dungeon" . && go build -o "${WORK_DIR}/llm-stub" ./tests/llm-stub) || exit 1
+
+"${WORK_DIR}/llm-stub" --port "${PORT}" > "${WORK_DIR}/stub.log" 2>&1 &
+STUB_PID=$!
+for i in $(seq 1 50); do
+  curl -s -o /dev/null "http://localhost:${PORT}/" && break
+  sleep 0.2
+done
+
+"${WORK_DIR}/mcp-dungeon" import-plan "${ROOT_DIR}/specs/dungeon.plan.md" --output "${WORK_DIR}/skeleton.yaml" || exit 1
+"${WORK_DIR}/mcp-dungeon" enrich-dungeon "${WORK_DIR}/skeleton.yaml" \
+  --output "${WORK_DIR}/enriched.yaml" \
+  --llm-base-url "http://localhost:${PORT}/v1" \
+  --chat-model stub || exit 1
+
+STRUCTURE='^ *(id|type|coordinates|connections|entrance_room|exit_room):'
+if ! diff <(grep -E "${STRUCTURE}" "${WORK_DIR}/skeleton.yaml") <(grep -E "${STRUCTURE}" "${WORK_DIR}/enriched.yaml"); then
+  echo "🔴 The structure of the dungeon changed"
+  exit 1
+fi
+
+if grep -q '^    description: ""' "${WORK_DIR}/enriched.yaml"; then
+  echo "🔴 Some locations have no description"
+  exit 1
+fi
+
+"${WORK_DIR}/mcp-dungeon" validate "${WORK_DIR}/enriched.yaml" || exit 1
+
+# Monster and NPC names are written too
+"${WORK_DIR}/mcp-dungeon" generate-dungeon --seed 7 --output "${WORK_DIR}/generated.yaml" || exit 1
+"${WORK_DIR}/mcp-dungeon" enrich-dungeon "${WORK_DIR}/generated.yaml" \
+  --output "${WORK_DIR}/generated_enriched.yaml" \
+  --llm-base-url "http://localhost:${PORT}/v1" \
+  --chat-model stub > /dev/null 2>&1 || exit 1
+if ! grep -q 'name: Stub monster of' "${WORK_DIR}/generated_enriched.yaml" || ! grep -q 'name: Stub NPC of' "${WORK_DIR}/generated_enriched.yaml"; then
+  echo "🔴 The monster and NPC names were not written"
+  exit 1
+fi
+echo "🟢 The dungeon was enriched without changing its structure"
@@ -0,0 +1,80 @@
+// Command llm-stub is a fake OpenAI-compatible chat completion server for the tests.
+// Its answers only depend on the prompt, so the test results are reproducible.
+//
+// Usage: go run ./tests/llm-stub --port 12500
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type chatRequest struct {
+	Model    string `json:"model"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"messages"`
+}
+
+var locationPattern = regexp.MustCompile(`Location: (\S+),`)
+
+// answer builds the enrichment JSON for the enrich-dungeon prompts,
+// and echoes the last user message otherwise.
+func answer(request chatRequest) string {
+	if len(request.Messages) == 0 {
+		return ""
+	}
+	prompt := request.Messages[len(request.Messages)-1].Content
+
+	if match := locationPattern.FindStringSubmatch(prompt); match != nil {
+		id := match[1]
+		texts := map[string]string{"description": "Stub description of " + id}
+		if strings.Contains(prompt, `"monster_name"`) {
+			texts["monster_name"] = "Stub monster of " + id
+			texts["monster_description"] = "Stub monster description of " + id
+		}
+		if strings.Contains(prompt, `"npc_name"`) {
+			texts["npc_name"] = "Stub NPC of " + id
+			texts["npc_description"] = "Stub NPC description of " + id
+		}
+		data, _ := json.Marshal(texts)
+		return "```json\n" + string(data) + "\n```"
+	}
+
+	return fmt.Sprintf("Stub answer #%d to: %s", len(request.Messages), prompt)
+}
+
+func main() {
+	port := flag.String("port", "12500", "HTTP port")
+	flag.Parse()
+
+	http.HandleFunc("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var request chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":      "stub",
+			"object":  "chat.completion",
+			"created": 0,
+			"model":   request.Model,
+			"choices": []map[string]any{{
+				"index":         0,
+				"finish_reason": "stop",
+				"message":       map[string]string{"role": "assistant", "content": answer(request)},
+			}},
+		})
+	})
+
+	log.Printf("LLM stub listening on port %s", *port)
+	log.Fatal(http.ListenAndServe(":"+*port, nil))
+}
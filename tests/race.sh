@@ -0,0 +1,100 @@
+#!/bin/bash
+: <<'COMMENT'
+# Race test suite
+
+Builds the server with the Go race detector, then hammers the move
+and status tools in parallel, both inside one session and across
+several sessions. Fails if the race detector reports anything or if
+a player ends up with a location that does not match its coordinates.
+
+Usage: ./race.sh [number of parallel workers] [calls per worker]
+COMMENT
+
+WORKERS=${1:-8}
+CALLS=${2:-50}
+PORT=${RACE_PORT:-9191}
+MCP_SERVER="http://localhost:${PORT}"
+
+ROOT_DIR="$(cd "$(dirname "$0")/.." && pwd)"
+WORK_DIR=$(mktemp -d)
+trap 'kill ${SERVER_PID} 2>/dev/null; wait ${SERVER_PID} 2>/dev/null; rm -rf "${WORK_DIR}"' EXIT
+
+echo "🔨 Building the server with -race..."
+(cd "${ROOT_DIR}" && go build -race -o "${WORK_DIR}/mcp-dungeon" .) || exit 1
+
+"${WORK_DIR}/mcp-dungeon" --dungeon-file "${ROOT_DIR}/crystal_caverns.yaml" --port "${PORT}" --save-dir "${WORK_DIR}/saves" > "${WORK_DIR}/server.log" 2>&1 &
+SERVER_PID=$!
+
+for i in $(seq 1 50); do
+  curl -s "${MCP_SERVER}/health" > /dev/null && break
+  sleep 0.2
+done
+
+function init_session() {
+  curl -i -s -X POST \
+    -H "Content-Type: application/json" \
+    -d '{"jsonrpc": "2.0", "method": "initialize", "id": "init", "params": {"protocolVersion": "2024-11-05"}}' \
+    "${MCP_SERVER}/mcp" | grep -i "mcp-session-id:" | cut -d' ' -f2 | tr -d '\r\n'
+}
+
+function call_tool() {
+  local session_id="$1"
+  local tool_name="$2"
+  local tool_arguments="$3"
+  curl -s -X POST \
+    -H "Content-Type: application/json" \
+    -H "Mcp-Session-Id: ${session_id}" \
+    -d "{\"jsonrpc\": \"2.0\", \"id\": \"call\", \"method\": \"tools/call\", \"params\": {\"name\": \"${tool_name}\", \"arguments\": ${tool_arguments}}}" \
+    "${MCP_SERVER}/mcp"
+}
+
+function hammer() {
+  local session_id="$1"
+  local n="$2"
+  case $((n % 4)) in
+    0) call_tool "${session_id}" move_to_room_by_name '{"target_room": "crystal_workshop"}' ;;
+    1) call_tool "${session_id}" get_player_status '{}' ;;
+    2) call_tool "${session_id}" move_to_room_by_name '{"target_room": "entrance_cave"}' ;;
+    3) call_tool "${session_id}" display_dungeon_map '{}' ;;
+  esac > /dev/null
+}
+export -f call_tool hammer
+export MCP_SERVER
+
+SHARED_SESSION=$(init_session)
+SESSIONS=("${SHARED_SESSION}")
+for w in $(seq 1 "${WORKERS}"); do
+  SESSIONS+=("$(init_session)")
+done
+
+echo "🏃 ${WORKERS} parallel workers x ${CALLS} calls on a shared session and on ${WORKERS} private sessions..."
+for n in $(seq 1 $((WORKERS * CALLS))); do
+  echo "${SHARED_SESSION} ${n}"
+  echo "${SESSIONS[$(( (n % WORKERS) + 1 ))]} ${n}"
+done | xargs -P $((WORKERS * 2)) -n 2 bash -c 'hammer "$0" "$1"'
+
+FAILED=0
+
+for session_id in "${SESSIONS[@]}"; do
+  STATUS=$(call_tool "${session_id}" get_player_status '{}' | jq -r '.result.content[0].text')
+  LOCATION=$(echo "${STATUS}" | jq -r '.current_location')
+  COORDINATES=$(echo "${STATUS}" | jq -c '.coordinates')
+  case "${LOCATION}:${COORDINATES}" in
+    "entrance_cave:[2,5]"|"crystal_workshop:[2,4]") ;;
+    *)
+      echo "❌ Inconsistent player state in ${session_id}: ${LOCATION} ${COORDINATES}"
+      FAILED=1
+      ;;
+  esac
+done
+
+if grep -q "WARNING: DATA RACE" "${WORK_DIR}/server.log"; then
+  echo "❌ Data race detected:"
+  grep -A 30 "WARNING: DATA RACE" "${WORK_DIR}/server.log" | head -60
+  FAILED=1
+fi
+
+if [ "${FAILED}" -eq 0 ]; then
+  echo "✅ No data race detected"
+fi
+exit ${FAILED}
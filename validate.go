@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"mcp-dungeon/validator"
+)
+
+// checkDungeonFile validates a dungeon file and logs every issue found.
+func checkDungeonFile(filename string) error {
+	issues, err := validator.ValidateDungeonFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read dungeon %s: %v", filename, err)
+	}
+
+	for _, issue := range issues {
+		log.Printf("🔴 %s: %s", filename, issue)
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("dungeon %s has %d issue(s)", filename, len(issues))
+	}
+	return nil
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	files := args
+	if len(files) == 0 {
+		files = []string{dungeonFile}
+	}
+
+	failed := 0
+	for _, filename := range files {
+		if err := checkDungeonFile(filename); err != nil {
+			log.Println(err)
+			failed++
+			continue
+		}
+		log.Printf("🟢 %s is valid", filename)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d dungeon file(s) are invalid", failed, len(files))
+	}
+	return nil
+}
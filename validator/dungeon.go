@@ -0,0 +1,397 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"mcp-dungeon/game"
+	"mcp-dungeon/models"
+)
+
+// Issue is a problem found in a dungeon definition.
+type Issue struct {
+	Line    int
+	Path    string
+	Message string
+}
+
+func (issue Issue) String() string {
+	if issue.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", issue.Line, issue.Path, issue.Message)
+	}
+	return fmt.Sprintf("%s: %s", issue.Path, issue.Message)
+}
+
+// ValidateDungeonFile checks a dungeon YAML file and reports issues with their line numbers.
+func ValidateDungeonFile(filename string) ([]Issue, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateDungeonYAML(data)
+}
+
+// ValidateDungeonYAML checks a dungeon YAML document and reports issues with their line numbers.
+func ValidateDungeonYAML(data []byte) ([]Issue, error) {
+	var dungeon models.Dungeon
+	if err := yaml.Unmarshal(data, &dungeon); err != nil {
+		return nil, err
+	}
+
+	lines, err := NewLineIndex(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return ValidateDungeon(&dungeon, lines), nil
+}
+
+// ValidateDungeon checks the structure of a dungeon and the rules of specs/specs.en.md.
+// lines can be nil when the dungeon does not come from a YAML file.
+func ValidateDungeon(dungeon *models.Dungeon, lines LineIndex) []Issue {
+	v := &validation{dungeon: dungeon, lines: lines}
+
+	v.checkDungeon()
+
+	// Iterate in a stable order so that reports are reproducible
+	ids := make([]string, 0, len(dungeon.Locations))
+	for id := range dungeon.Locations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	coordinates := map[[2]int]string{}
+	for _, id := range ids {
+		location := dungeon.Locations[id]
+		v.checkLocation(id, location)
+
+		if other, taken := coordinates[location.Coordinates]; taken {
+			v.report("locations."+id+".coordinates", "coordinates [%d, %d] are already used by '%s'",
+				location.Coordinates[0], location.Coordinates[1], other)
+		} else {
+			coordinates[location.Coordinates] = id
+		}
+	}
+
+	for i, obstacle := range dungeon.Obstacles {
+		path := fmt.Sprintf("obstacles[%d]", i)
+		x, y := obstacle[0], obstacle[1]
+		if x < 0 || x >= dungeon.Size.Width || y < 0 || y >= dungeon.Size.Height {
+			v.report(path, "obstacle [%d, %d] is outside the %dx%d dungeon", x, y, dungeon.Size.Width, dungeon.Size.Height)
+		}
+		if slices.Index(dungeon.Obstacles, obstacle) != i {
+			v.report(path, "obstacle [%d, %d] is listed twice", x, y)
+		}
+		if id, taken := coordinates[obstacle]; taken && dungeon.Locations[id].Type != models.LocationTypeObstacle {
+			v.report(path, "obstacle [%d, %d] is on location '%s'", x, y, id)
+		}
+	}
+
+	v.checkExitReachable()
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		return v.issues[i].Line < v.issues[j].Line
+	})
+	return v.issues
+}
+
+type validation struct {
+	dungeon *models.Dungeon
+	lines   LineIndex
+	issues  []Issue
+}
+
+func (v *validation) report(path, format string, args ...any) {
+	v.issues = append(v.issues, Issue{
+		Line:    v.lines.Line(path),
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validation) checkRange(path, name string, value, minValue, maxValue int) {
+	if value < minValue || value > maxValue {
+		v.report(path, "%s %d is out of range [%d, %d]", name, value, minValue, maxValue)
+	}
+}
+
+func (v *validation) checkEnum(path, name, value string, allowed []string) {
+	if !slices.Contains(allowed, value) {
+		v.report(path, "unknown %s '%s' (expected one of %v)", name, value, allowed)
+	}
+}
+
+func (v *validation) checkDungeon() {
+	d := v.dungeon
+
+	if d.Size.Width <= 0 || d.Size.Height <= 0 {
+		v.report("size", "size %dx%d must be positive", d.Size.Width, d.Size.Height)
+	}
+
+	if len(d.Locations) == 0 {
+		v.report("locations", "the dungeon has no locations")
+	}
+
+	if d.EntranceRoom == "" {
+		v.report("entrance_room", "entrance_room is missing")
+	} else if location, exists := d.Locations[d.EntranceRoom]; !exists {
+		v.report("entrance_room", "entrance room '%s' does not exist", d.EntranceRoom)
+	} else if location.Type == models.LocationTypeObstacle {
+		v.report("entrance_room", "entrance room '%s' is an obstacle", d.EntranceRoom)
+	}
+
+	if d.ExitRoom == "" {
+		v.report("exit_room", "exit_room is missing")
+	} else if location, exists := d.Locations[d.ExitRoom]; !exists {
+		v.report("exit_room", "exit room '%s' does not exist", d.ExitRoom)
+	} else if location.Type == models.LocationTypeObstacle {
+		v.report("exit_room", "exit room '%s' is an obstacle", d.ExitRoom)
+	}
+}
+
+func (v *validation) checkLocation(id string, location models.Location) {
+	path := "locations." + id
+	d := v.dungeon
+
+	if location.ID != id {
+		v.report(path+".id", "id '%s' does not match the map key '%s'", location.ID, id)
+	}
+
+	v.checkEnum(path+".type", "location type", location.Type, models.LocationTypes)
+
+	x, y := location.Coordinates[0], location.Coordinates[1]
+	if x < 0 || x >= d.Size.Width || y < 0 || y >= d.Size.Height {
+		v.report(path+".coordinates", "coordinates [%d, %d] are outside the %dx%d dungeon", x, y, d.Size.Width, d.Size.Height)
+	}
+
+	v.checkConnections(id, location)
+
+	if location.Monster != nil && location.NPC != nil {
+		v.report(path, "a location cannot have both a monster and an NPC")
+	}
+
+	if location.Guard != "" && location.Guard != game.GuardBlockExits {
+		v.report(path+".guard", "unknown guard rule '%s' (expected %s)", location.Guard, game.GuardBlockExits)
+	}
+
+	if location.NPC != nil {
+		v.checkEnum(path+".npc.type", "NPC type", location.NPC.Type, models.NPCTypes)
+		v.checkStock(path+".npc", *location.NPC)
+		if fee := location.NPC.HealingFee; fee < 0 {
+			v.report(path+".npc.healing_fee", "healing fee %d cannot be negative", fee)
+		} else if fee > 0 && location.NPC.Type != game.NPCTypeHealer {
+			v.report(path+".npc.healing_fee", "only a healer can have a healing fee, not a %s", location.NPC.Type)
+		}
+	}
+
+	if location.Treasure != nil {
+		v.checkTreasure(path+".treasure", *location.Treasure)
+	}
+
+	if monster := location.Monster; monster != nil {
+		v.checkEnum(path+".monster.type", "monster type", monster.Type, models.MonsterTypes)
+		v.checkRange(path+".monster.difficulty_level", "difficulty level", monster.DifficultyLevel,
+			models.MinDifficultyLevel, models.MaxDifficultyLevel)
+		v.checkRange(path+".monster.hit_points", "hit points", monster.HitPoints,
+			models.MinMonsterHitPoints, models.MaxMonsterHitPoints)
+		if monster.Treasure.Type != "" || monster.Treasure.Value != 0 {
+			v.checkTreasure(path+".monster.treasure", monster.Treasure)
+		}
+	}
+
+	for i, item := range location.Items {
+		v.checkItem(fmt.Sprintf("%s.items[%d]", path, i), item)
+	}
+
+	v.checkChecks(id, location)
+}
+
+func (v *validation) checkChecks(id string, location models.Location) {
+	path := "locations." + id
+	d := v.dungeon
+
+	for i, target := range location.LockedDoors {
+		lockedPath := fmt.Sprintf("%s.locked_doors[%d]", path, i)
+		if !slices.Contains(location.Connections, target) {
+			v.report(lockedPath, "locked door to '%s' is not listed in connections", target)
+		} else if slices.Index(location.LockedDoors, target) != i {
+			v.report(lockedPath, "locked door to '%s' is listed twice", target)
+		}
+	}
+
+	for i, check := range location.Checks {
+		checkPath := fmt.Sprintf("%s.checks[%d]", path, i)
+		if check.Name == "" {
+			v.report(checkPath, "a check must have a name")
+		} else if slices.IndexFunc(location.Checks, func(other models.Check) bool { return other.Name == check.Name }) != i {
+			v.report(checkPath+".name", "check '%s' is listed twice", check.Name)
+		}
+		v.checkEnum(checkPath+".skill", "skill", check.Skill, game.SkillNames)
+		v.checkRange(checkPath+".difficulty", "difficulty class", check.Difficulty,
+			models.MinDifficultyClass, models.MaxDifficultyClass)
+
+		if check.Opens != "" {
+			target, exists := d.Locations[check.Opens]
+			switch {
+			case !slices.Contains(location.Connections, check.Opens):
+				v.report(checkPath+".opens", "check opens '%s' which is not listed in connections", check.Opens)
+			case !exists || !game.IsDoorLocked(location, target):
+				v.report(checkPath+".opens", "check opens the door to '%s' which is not locked", check.Opens)
+			}
+		}
+
+		if check.Reward != nil {
+			v.checkTreasure(checkPath+".reward", *check.Reward)
+		}
+		if check.Experience < 0 {
+			v.report(checkPath+".experience", "experience %d cannot be negative", check.Experience)
+		}
+		if check.Damage < 0 {
+			v.report(checkPath+".damage", "damage %d cannot be negative", check.Damage)
+		}
+	}
+}
+
+func (v *validation) checkItem(path string, item models.Item) {
+	if item.Type == "" {
+		v.report(path, "an item must have a type")
+	}
+	if item.Quantity <= 0 {
+		v.report(path+".quantity", "quantity %d must be positive", item.Quantity)
+	}
+	if item.HealingLevel != 0 || item.Type == "healing_potion" {
+		v.checkRange(path+".healing_level", "healing level", item.HealingLevel,
+			models.MinHealingLevel, models.MaxHealingLevel)
+	}
+
+	if item.Slot == "" {
+		if item.AttackBonus != 0 || item.DefenseBonus != 0 {
+			v.report(path+".slot", "an item with attack or defense bonuses must have a slot %v", models.ItemSlots)
+		}
+		return
+	}
+	v.checkEnum(path+".slot", "item slot", item.Slot, models.ItemSlots)
+	if item.HealingLevel != 0 {
+		v.report(path+".healing_level", "an equipment cannot heal")
+	}
+	v.checkRange(path+".attack_bonus", "attack bonus", item.AttackBonus, models.MinItemBonus, models.MaxItemBonus)
+	v.checkRange(path+".defense_bonus", "defense bonus", item.DefenseBonus, models.MinItemBonus, models.MaxItemBonus)
+}
+
+func (v *validation) checkStock(path string, npc models.NPC) {
+	if len(npc.Stock) > 0 && npc.Type != game.NPCTypeMerchant {
+		v.report(path+".stock", "only a merchant can have a stock, not a %s", npc.Type)
+	}
+
+	for i, ware := range npc.Stock {
+		warePath := fmt.Sprintf("%s.stock[%d]", path, i)
+		v.checkItem(warePath, ware.Item)
+		if ware.Price <= 0 {
+			v.report(warePath+".price", "price %d must be positive", ware.Price)
+		}
+	}
+}
+
+func (v *validation) checkTreasure(path string, treasure models.Treasure) {
+	v.checkEnum(path+".type", "treasure type", treasure.Type, models.TreasureTypes)
+	v.checkRange(path+".value", "treasure value", treasure.Value, models.MinTreasureValue, models.MaxTreasureValue)
+}
+
+func (v *validation) checkConnections(id string, location models.Location) {
+	path := "locations." + id
+	d := v.dungeon
+
+	if location.Type == models.LocationTypeObstacle {
+		if len(location.Connections) > 0 {
+			v.report(path+".connections", "obstacle '%s' cannot have connections", id)
+		}
+		return
+	}
+
+	if count := len(location.Connections); count < models.MinDoors || count > models.MaxDoors {
+		v.report(path+".connections", "%d doors, a location must have between %d and %d doors",
+			count, models.MinDoors, models.MaxDoors)
+	}
+
+	for i, target := range location.Connections {
+		connectionPath := fmt.Sprintf("%s.connections[%d]", path, i)
+
+		if target == id {
+			v.report(connectionPath, "'%s' is connected to itself", id)
+			continue
+		}
+
+		if slices.Index(location.Connections, target) != i {
+			v.report(connectionPath, "connection to '%s' is listed twice", target)
+			continue
+		}
+
+		targetLocation, exists := d.Locations[target]
+		if !exists {
+			v.report(connectionPath, "connection to '%s' which does not exist", target)
+			continue
+		}
+
+		if targetLocation.Type == models.LocationTypeObstacle {
+			v.report(connectionPath, "connection to '%s' which is an obstacle", target)
+			continue
+		}
+
+		if !slices.Contains(targetLocation.Connections, id) && !slices.Contains(location.OneWay, target) {
+			v.report(connectionPath, "'%s' leads to '%s' but '%s' does not lead back (list it in one_way if intended)",
+				id, target, target)
+		}
+	}
+
+	for i, target := range location.OneWay {
+		if !slices.Contains(location.Connections, target) {
+			v.report(fmt.Sprintf("%s.one_way[%d]", path, i), "one-way connection to '%s' is not listed in connections", target)
+		}
+	}
+}
+
+func (v *validation) checkExitReachable() {
+	d := v.dungeon
+	if _, exists := d.Locations[d.EntranceRoom]; !exists {
+		return
+	}
+	if _, exists := d.Locations[d.ExitRoom]; !exists {
+		return
+	}
+
+	// A locked door only counts when a check can open it
+	openable := map[string]bool{}
+	for id, location := range d.Locations {
+		for _, check := range location.Checks {
+			if check.Opens != "" {
+				openable[game.DoorID(id, check.Opens)] = true
+			}
+		}
+	}
+
+	visited := map[string]bool{d.EntranceRoom: true}
+	queue := []string{d.EntranceRoom}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range d.Locations[id].Connections {
+			location, exists := d.Locations[next]
+			if !exists || visited[next] || game.IsObstacleLocation(d, location) {
+				continue
+			}
+			if game.IsDoorLocked(d.Locations[id], location) && !openable[game.DoorID(id, next)] {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, next)
+		}
+	}
+
+	if !visited[d.ExitRoom] {
+		v.report("exit_room", "exit room '%s' cannot be reached from entrance room '%s'", d.ExitRoom, d.EntranceRoom)
+	}
+}
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LineIndex maps a YAML path (like "locations.armory.connections[1]") to its line number.
+type LineIndex map[string]int
+
+// NewLineIndex records the line of every mapping key and sequence entry of a YAML document.
+func NewLineIndex(data []byte) (LineIndex, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	index := LineIndex{}
+	if len(root.Content) > 0 {
+		index.walk("", root.Content[0])
+	}
+	return index, nil
+}
+
+func (index LineIndex) walk(path string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := key.Value
+			if path != "" {
+				childPath = path + "." + key.Value
+			}
+			index[childPath] = key.Line
+			index.walk(childPath, value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			index[childPath] = item.Line
+			index.walk(childPath, item)
+		}
+	}
+}
+
+// Line returns the line of a path, falling back to its closest parent, or 0 if unknown.
+func (index LineIndex) Line(path string) int {
+	for path != "" {
+		if line, exists := index[path]; exists {
+			return line
+		}
+		cut := max(strings.LastIndex(path, "."), strings.LastIndex(path, "["))
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return 0
+}